@@ -25,7 +25,6 @@ package main
 import (
 	"fmt"
 	gm "github.com/ericdfournier/gmaps/lib"
-	"googlemaps.github.io/maps"
 	"gopkg.in/urfave/cli.v1"
 	"os"
 	"sort"
@@ -37,6 +36,190 @@ var apiKey string = ""
 var input string = ""
 var output string = ""
 var region string = ""
+var fields string = ""
+var maxResults int = 1
+var rejects string = ""
+var format string = "csv"
+var geometry string = "point"
+var table string = "results"
+var radius int = 5000
+var inputType string = "textquery"
+var mode string = "place"
+var travelMode string = "driving"
+var maxElements int = 100
+var layout string = "long"
+var matrixValue string = "duration"
+var overlap int = 10
+var speedUnits string = "KPH"
+var workers int = 1
+var qps int = 0
+var dailyLimit int = 0
+var maxRetries int = 3
+var checkpoint string = ""
+var cacheFile string = ""
+var usageFile string = ""
+var cacheTTL time.Duration = 30 * 24 * time.Hour
+
+// Input Column Flags Shared by All Request Sub-Commands
+var inputFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "header",
+		Usage: "Input Begins with a Header Row [Default for Input FILEPATH]",
+	},
+	cli.BoolFlag{
+		Name:  "no-header",
+		Usage: "Input Has No Header Row [Default for STDIN]",
+	},
+	cli.BoolFlag{
+		Name:  "passthrough",
+		Usage: "Copy Unmapped Input Columns to Output",
+	},
+	cli.StringFlag{
+		Name:  "rejects",
+		Usage: "Rejected Input Rows FILEPATH [Default: STDERR]",
+		Value: rejects,
+	},
+	cli.StringFlag{
+		Name:  "id-col",
+		Usage: "Input 'id' Column Name or Index",
+		Value: "0",
+	},
+}
+
+// Trace Input Flags Shared by Roads Sub-Commands
+var roadsFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "trace-col",
+		Usage: "Input 'trace' Id Column Name or Index [Rows Contiguous by Trace]",
+		Value: "1",
+	},
+	cli.StringFlag{
+		Name:  "lat-col",
+		Usage: "Input 'lat' Column Name or Index",
+		Value: "2",
+	},
+	cli.StringFlag{
+		Name:  "lng-col",
+		Usage: "Input 'lng' Column Name or Index",
+		Value: "3",
+	},
+	cli.IntFlag{
+		Name:  "overlap",
+		Usage: "Points Shared Between Consecutive 100 Point Windows",
+		Value: overlap,
+	},
+}
+
+// Output Format Flags Shared by All Request Sub-Commands
+var outputFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "format, f",
+		Usage: "Output 'Format' [csv, json, jsonl, geojson, kml, gpx, sqlite, gpkg]",
+		Value: format,
+	},
+	cli.BoolFlag{
+		Name:  "no-output-header",
+		Usage: "Omit Header Row from CSV Output",
+	},
+	cli.StringFlag{
+		Name:  "table",
+		Usage: "Output SQLite or GeoPackage 'Table' Name",
+		Value: table,
+	},
+}
+
+// Place Search Filter Flags Shared by Place Search Sub-Commands
+var placeFilterFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "type",
+		Usage: "Restrict Results to a Place 'Type'",
+	},
+	cli.StringFlag{
+		Name:  "language",
+		Usage: "Result 'Language' Code",
+	},
+	cli.StringFlag{
+		Name:  "min-price",
+		Usage: "Minimum Place 'Price' Level [0-4]",
+	},
+	cli.StringFlag{
+		Name:  "max-price",
+		Usage: "Maximum Place 'Price' Level [0-4]",
+	},
+	cli.BoolFlag{
+		Name:  "open-now",
+		Usage: "Restrict Results to Places Open Now",
+	},
+}
+
+// Batch Processing Flags Shared by All Request Sub-Commands
+var batchFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "workers, w",
+		Usage: "Number of Concurrent API Request 'Workers'",
+		Value: workers,
+	},
+	cli.BoolFlag{
+		Name:  "ordered",
+		Usage: "Restore Output Records to Input Order",
+	},
+	cli.IntFlag{
+		Name:  "qps",
+		Usage: "Maximum API 'Queries Per Second' [0 = Unlimited]",
+		Value: qps,
+	},
+	cli.IntFlag{
+		Name:  "daily-limit",
+		Usage: "Maximum API Queries Submitted per Pacific Time Day Across Runs [0 = Unlimited]",
+		Value: dailyLimit,
+	},
+	cli.StringFlag{
+		Name:  "usage-file",
+		Usage: "Daily Query Count FILEPATH [Default: ~/gmaps_usage.db]",
+		Value: usageFile,
+	},
+	cli.IntFlag{
+		Name:  "max-retries",
+		Usage: "Maximum Retries for Transient API Request Failures",
+		Value: maxRetries,
+	},
+	cli.StringFlag{
+		Name:  "checkpoint",
+		Usage: "Checkpoint FILEPATH for Resuming Interrupted Runs",
+		Value: checkpoint,
+	},
+	cli.BoolFlag{
+		Name:   "cache",
+		Usage:  "Consult Response Cache Before Submitting API Requests",
+		EnvVar: "GMAPS_CACHE",
+	},
+	cli.BoolFlag{
+		Name:  "no-cache",
+		Usage: "Bypass Response Cache",
+	},
+}
+
+// Response Cache Flags Shared by Request and Cache Sub-Commands
+var cacheFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "cache-file",
+		Usage: "Response Cache FILEPATH [Default: ~/gmaps_cache.db]",
+		Value: cacheFile,
+	},
+	cli.DurationFlag{
+		Name:  "cache-ttl",
+		Usage: "Maximum Age of Cached Responses [0 = No Expiry]",
+		Value: cacheTTL,
+	},
+}
+
+// Function to Concatenate Sub-Command Flag Sets
+func flagSet(sets ...[]cli.Flag) (flags []cli.Flag) {
+	for _, set := range sets {
+		flags = append(flags, set...)
+	}
+	return flags
+}
 
 // Function to Check if Inputs Can Be Sourced from Piped Stdin
 func CheckCharDevice() (info os.FileInfo) {
@@ -50,12 +233,10 @@ func CheckCharDevice() (info os.FileInfo) {
 
 // Function for Parsing Command Line Arguments
 func CheckArgs(con *cli.Context) (e error) {
-	// Allocated empty error
-	var err error
 	// Get stdin
 	info := CheckCharDevice()
 	// Check if input flag is set
-	if con.IsSet("input") != true && info.Size() <= 0 {
+	if con.IsSet("input") != true && info.Mode()&os.ModeCharDevice != 0 {
 		return cli.NewExitError("ERROR: Must Recieve STDIN or Provide Input Filepath", 1)
 	}
 	// Check if input file exists
@@ -69,6 +250,61 @@ func CheckArgs(con *cli.Context) (e error) {
 	if con.IsSet("key") != true {
 		return cli.NewExitError("ERROR: Must Provide Valid API Key", 3)
 	}
+	// Check output flags before any requests are sent
+	return gm.CheckOutput(con)
+}
+
+// Function for Checking Distance Matrix Input Arguments
+func CheckMatrixArgs(con *cli.Context) (e error) {
+	// Check if origins and destinations files exist
+	for _, name := range []string{"origins", "destinations"} {
+		if con.IsSet(name) != true {
+			return cli.NewExitError(fmt.Sprintf("ERROR: Must Provide '%s' Filepath", name), 1)
+		}
+		_, err := os.Stat(con.String(name))
+		if os.IsNotExist(err) {
+			return cli.NewExitError(fmt.Sprintf("ERROR: '%s' Filepath Does Not Exist", name), 2)
+		}
+	}
+	// Check layout and value flags
+	if layout := con.String("layout"); layout != "long" && layout != "wide" {
+		return cli.NewExitError(fmt.Sprintf("ERROR: Layout '%s' Must Be 'long' or 'wide'", layout), 1)
+	}
+	if value := con.String("value"); value != "duration" && value != "distance" {
+		return cli.NewExitError(fmt.Sprintf("ERROR: Value '%s' Must Be 'duration' or 'distance'", value), 1)
+	}
+	// Check if api key flag is set
+	if con.IsSet("key") != true {
+		return cli.NewExitError("ERROR: Must Provide Valid API Key", 3)
+	}
+	// Check output flags before any requests are sent
+	return gm.CheckOutput(con)
+}
+
+// Function for Reporting Fatal Errors on Stderr and Exiting
+func ExitOnError(err error) {
+	// Keep stdout free for streamed results
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+// Function for Reporting Rejected Input Rows
+func CheckRejects(rej *gm.Rejects) (e error) {
+	// Close rejects file
+	err := rej.Close()
+	if err != nil {
+		return cli.NewExitError(err.Error(), 2)
+	}
+	// Fail on truncated input
+	if err := rej.Err(); err != nil {
+		return cli.NewExitError(fmt.Sprintf("ERROR: Input Read Failed: %v", err), 2)
+	}
+	// Summarize rejected row count
+	if n := rej.Count(); n > 0 {
+		return cli.NewExitError(fmt.Sprintf("ERROR: %d Input Rows Rejected", n), 4)
+	}
 	return err
 }
 
@@ -101,8 +337,9 @@ func main() {
 				... ,
 				lat - [float],
 				lng - [float],
+				status - [string],
 				note - [string]`,
-			Flags: []cli.Flag{
+			Flags: flagSet([]cli.Flag{
 				cli.StringFlag{
 					Name:   "key, k",
 					Usage:  "Google Maps Geocoder API 'Key'",
@@ -117,6 +354,31 @@ func main() {
 						address - [string]`,
 					Value: input,
 				},
+				cli.StringFlag{
+					Name:  "address-col",
+					Usage: "Input 'address' Column Name or Index",
+					Value: "1",
+				},
+				cli.StringFlag{
+					Name:  "street-col",
+					Usage: "Input Structured Address 'street' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "city-col",
+					Usage: "Input Structured Address 'city' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "state-col",
+					Usage: "Input Structured Address 'state' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "postal-code-col",
+					Usage: "Input Structured Address 'postal code' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "country-col",
+					Usage: "Input Structured Address 'country' Column Name or Index",
+				},
 				cli.StringFlag{
 					Name: "output, o",
 					Usage: `
@@ -124,6 +386,7 @@ func main() {
 						... ,
 						lat - [float],
 						lng - [float],
+						status - [string],
 						note - [string]`,
 					Value: output,
 				},
@@ -132,45 +395,45 @@ func main() {
 					Usage: "Restricted 'Region Code'",
 					Value: region,
 				},
-			},
+				cli.IntFlag{
+					Name:  "max-results",
+					Usage: "Maximum Ranked Candidate Results per Input Record",
+					Value: maxResults,
+				},
+				cli.StringFlag{
+					Name: "fields",
+					Usage: `Comma Separated Output Fields, 'default' or 'all':
+						id, address, lat, lng, formatted_address, place_id,
+						location_type, partial_match, types, street_number,
+						route, locality, county, state, postal_code, country,
+						status, note`,
+					Value: fields,
+				},
+			}, inputFlags, outputFlags, batchFlags, cacheFlags),
 			Action: func(con *cli.Context) (e error) {
 				// Check input arguments
 				err := CheckArgs(con)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				ExitOnError(err)
 				// Establish new Google Maps API client connection
 				clt, err := gm.ConnectClient(con)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				ExitOnError(err)
 				// Authenticate client IP
 				err = gm.CheckClientIP(con, clt)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				ExitOnError(err)
+				// Open rejects file for invalid input rows
+				rej, err := gm.OpenRejects(con)
+				ExitOnError(err)
 				// Read in address data from csv file
-				rec, err := gm.GeocodeReadInput(con)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				rec, err := gm.GeocodeReadInput(con, rej)
+				ExitOnError(err)
 				// Geocode records from input csv file records
 				res, err := gm.GeocodeRecords(con, clt, rec)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				ExitOnError(err)
 				// Write formatted output to csv file
 				err = gm.GeocodeWriteOutput(con, res)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
-				return err
+				ExitOnError(err)
+				// Report rejected input rows
+				return CheckRejects(rej)
 			},
 		},
 		// Reverse Geocoder API Sub-Command
@@ -187,8 +450,9 @@ func main() {
 			Output STDOUT Format:
 				... ,
 				address - [string],
+				status - [string],
 				note - [string]`,
-			Flags: []cli.Flag{
+			Flags: flagSet([]cli.Flag{
 				cli.StringFlag{
 					Name:   "key, k",
 					Usage:  "Google Maps Reverse Geocoder API 'Key'",
@@ -204,12 +468,23 @@ func main() {
 						lng - [float]`,
 					Value: input,
 				},
+				cli.StringFlag{
+					Name:  "lat-col",
+					Usage: "Input 'lat' Column Name or Index",
+					Value: "1",
+				},
+				cli.StringFlag{
+					Name:  "lng-col",
+					Usage: "Input 'lng' Column Name or Index",
+					Value: "2",
+				},
 				cli.StringFlag{
 					Name: "output, o",
 					Usage: `
 					Output FILEPATH Format: 
 						... ,
 						address - [string], 
+						status - [string],
 						note - [string]`,
 					Value: output,
 				},
@@ -218,52 +493,48 @@ func main() {
 					Usage: "Restricted 'Region Code'",
 					Value: region,
 				},
-			},
+				cli.StringFlag{
+					Name: "fields",
+					Usage: `Comma Separated Output Fields, 'default' or 'all':
+						id, address, lat, lng, formatted_address, place_id,
+						location_type, partial_match, types, street_number,
+						route, locality, county, state, postal_code, country,
+						status, note`,
+					Value: fields,
+				},
+			}, inputFlags, outputFlags, batchFlags, cacheFlags),
 			Action: func(con *cli.Context) (e error) {
 				// Check input arguments
 				err := CheckArgs(con)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				ExitOnError(err)
 				// Establish new Google Maps API client connection
 				clt, err := gm.ConnectClient(con)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				ExitOnError(err)
 				// Authenticate client IP
 				err = gm.CheckClientIP(con, clt)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				ExitOnError(err)
+				// Open rejects file for invalid input rows
+				rej, err := gm.OpenRejects(con)
+				ExitOnError(err)
 				// Read in address data from csv file
-				rec, err := gm.ReverseGeocodeReadInput(con)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				rec, err := gm.ReverseGeocodeReadInput(con, rej)
+				ExitOnError(err)
 				// Geocode records from input csv file records
 				res, err := gm.ReverseGeocodeRecords(con, clt, rec)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
+				ExitOnError(err)
 				// Write formatted output to csv file
 				err = gm.ReverseGeocodeWriteOutput(con, res)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
-				return err
+				ExitOnError(err)
+				// Report rejected input rows
+				return CheckRejects(rej)
 			},
 		},
 		{
 			Name:  "place",
 			Usage: "Google Maps Places API Tool",
-			Description: `Options for searching for nearby places or accessing 
-			detailed place information from the Google Maps Place API.`,
+			Description: `Options for searching for nearby places, searching by free
+			text, matching place IDs, predicting places from partial input or
+			accessing detailed place information from the Google Maps Place API.`,
 			Subcommands: []cli.Command{
 				{
 					Name:  "nearby",
@@ -280,8 +551,9 @@ func main() {
 						placeId - [string],
 						name - [string],
 						type - [string],
+						status - [string],
 						note - [string]`,
-					Flags: []cli.Flag{
+					Flags: flagSet([]cli.Flag{
 						cli.StringFlag{
 							Name:   "key, k",
 							Usage:  "Google Place API 'Key'",
@@ -298,6 +570,75 @@ func main() {
 								radius - [int]`,
 							Value: input,
 						},
+						cli.StringFlag{
+							Name:  "lat-col",
+							Usage: "Input 'lat' Column Name or Index",
+							Value: "1",
+						},
+						cli.StringFlag{
+							Name:  "lng-col",
+							Usage: "Input 'lng' Column Name or Index",
+							Value: "2",
+						},
+						cli.StringFlag{
+							Name:  "radius-col",
+							Usage: "Input 'radius' Column Name or Index",
+							Value: "3",
+						},
+						cli.StringFlag{
+							Name:  "keyword",
+							Usage: "Search 'Keyword' Matched Against Place Content",
+						},
+						cli.StringFlag{
+							Name:  "name",
+							Usage: "Search Place 'Name' Terms",
+						},
+						cli.StringFlag{
+							Name:  "rank-by",
+							Usage: "Result 'Rank By' Order [prominence, distance]",
+						},
+						cli.StringFlag{
+							Name:  "keyword-col",
+							Usage: "Input Per-Row 'keyword' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "name-col",
+							Usage: "Input Per-Row 'name' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "type-col",
+							Usage: "Input Per-Row 'type' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "language-col",
+							Usage: "Input Per-Row 'language' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "min-price-col",
+							Usage: "Input Per-Row 'min_price' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "max-price-col",
+							Usage: "Input Per-Row 'max_price' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "open-now-col",
+							Usage: "Input Per-Row 'open_now' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "rank-by-col",
+							Usage: "Input Per-Row 'rank_by' Column Name or Index",
+						},
+						cli.IntFlag{
+							Name:  "max-results",
+							Usage: "Maximum Ranked Candidate Results per Input Record [Up to 60]",
+							Value: maxResults,
+						},
+						cli.StringFlag{
+							Name:  "geometry",
+							Usage: "GeoJSON Place 'Geometry' [point, viewport, bounds]",
+							Value: geometry,
+						},
 						cli.StringFlag{
 							Name: "output, o",
 							Usage: `
@@ -306,57 +647,63 @@ func main() {
 								placeId - [string],
 								name - [string],
 								type - [string],
+								status - [string],
 								note - [string]`,
 							Value: output,
 						},
-					},
+					}, placeFilterFlags, inputFlags, outputFlags, batchFlags, cacheFlags),
 					Action: func(con *cli.Context) (e error) {
 						// Check input arguments
 						err := CheckArgs(con)
-						if err != nil {
-							fmt.Println(err)
-							os.Exit(2)
-						}
+						ExitOnError(err)
 						// Establish new Google Maps API client connections
 						clt, err := gm.ConnectClient(con)
-						if err != nil {
-							fmt.Println(err)
-							os.Exit(2)
-						}
+						ExitOnError(err)
 						// Authenticate client IP
 						err = gm.CheckClientIP(con, clt)
-						if err != nil {
-							fmt.Println(err)
-							os.Exit(2)
-						}
+						ExitOnError(err)
+						// Open rejects file for invalid input rows
+						rej, err := gm.OpenRejects(con)
+						ExitOnError(err)
 						// Read in coordinate data from csv file
-						rec, err := gm.PlaceNearbyReadInput(con)
-						if err != nil {
-							fmt.Println(err)
-							os.Exit(2)
-						}
+						rec, err := gm.PlaceNearbyReadInput(con, rej)
+						ExitOnError(err)
 						// Request place data from input CSV file records
 						res, err := gm.PlaceNearbyRecords(con, clt, rec)
-						if err != nil {
-							fmt.Println(err)
-							os.Exit(2)
-						}
+						ExitOnError(err)
 						// Write formatted output to CSV file
 						err = gm.PlaceNearbyWriteOutput(con, res)
-						if err != nil {
-							fmt.Println(err)
-							os.Exit(2)
-						}
-						return err
+						ExitOnError(err)
+						// Report rejected input rows
+						return CheckRejects(rej)
 					},
 				},
 				{
 					Name:  "detail",
 					Usage: "Search for specific details by google place ID",
-					Description: `Accests an Input CSV File With Formated Google
-					Location IDs and Outputs a Formatted CSV File with Placed
-					Response Details.`,
-					Flags: []cli.Flag{
+					Description: `
+					Accepts STDIN or Input FILEPATH [CSV].
+					Outputs STDOUT or Output FILEPATH [CSV].
+					Input STDIN Format:
+						id - [string],
+						placeId - [string]
+					Output STDOUT Format:
+						...,
+						name - [string],
+						formatted_address - [string],
+						phone - [string],
+						website - [string],
+						rating - [float],
+						user_ratings_total - [int],
+						opening_hours - [string],
+						types - [string],
+						lat - [float],
+						lng - [float],
+						viewport - [string],
+						bounds - [string],
+						status - [string],
+						note - [string]`,
+					Flags: flagSet([]cli.Flag{
 						cli.StringFlag{
 							Name:   "key, k",
 							Usage:  "Google Maps Places API 'Key'",
@@ -371,117 +718,1191 @@ func main() {
 								placeId - [string]`,
 							Value: input,
 						},
+						cli.StringFlag{
+							Name:  "place-id-col",
+							Usage: "Input 'placeId' Column Name or Index",
+							Value: "1",
+						},
+						cli.StringFlag{
+							Name:  "geometry",
+							Usage: "GeoJSON Place 'Geometry' [point, viewport, bounds]",
+							Value: geometry,
+						},
 						cli.StringFlag{
 							Name: "output, o",
 							Usage: `
 							Output Format:
 								...,
-								TBD`,
+								name - [string],
+								formatted_address - [string],
+								phone - [string],
+								website - [string],
+								rating - [float],
+								user_ratings_total - [int],
+								opening_hours - [string],
+								types - [string],
+								lat - [float],
+								lng - [float],
+								viewport - [string],
+								bounds - [string],
+								status - [string],
+								note - [string]`,
 							Value: output,
 						},
-					},
+					}, inputFlags, outputFlags, batchFlags, cacheFlags),
 					Action: func(con *cli.Context) (e error) {
 						// Check input arguments
 						err := CheckArgs(con)
-						if err != nil {
-							fmt.Println(err)
-							os.Exit(2)
-						}
+						ExitOnError(err)
 						// Establish new Google Maps API client connections
-//						clt, err := gm.ConnectClient(con)
-//						if err != nil {
-//							fmt.Println(err)
-//							os.Exit(2)
-//						}
-						return
+						clt, err := gm.ConnectClient(con)
+						ExitOnError(err)
+						// Authenticate client IP
+						err = gm.CheckClientIP(con, clt)
+						ExitOnError(err)
+						// Open rejects file for invalid input rows
+						rej, err := gm.OpenRejects(con)
+						ExitOnError(err)
+						// Read in place ID data from csv file
+						rec, err := gm.PlaceDetailsReadInput(con, rej)
+						ExitOnError(err)
+						// Request place details from input CSV file records
+						res, err := gm.PlaceDetailRecords(con, clt, rec)
+						ExitOnError(err)
+						// Write formatted output to CSV file
+						err = gm.PlaceDetailWriteOutput(con, res)
+						ExitOnError(err)
+						// Report rejected input rows
+						return CheckRejects(rej)
 					},
 				},
-			},
-		},
-		{
-			Name:  "elevation",
-			Usage: "Google Maps Elevation API Tool",
-			Description: `
-			Accepts STDIN or Input FILEPATH [CSV]. 
-			Outputs STDOUT or Output FILEPATH [CSV].
-			Input STDIN Format: 
-				id - [string],
-				lat - [float],
-				lng - [float]
-			Output STDOUT Format:
-				... ,
-				elevation - [float],
-				resolution - [float],
-				note - [string]`,
-			Flags: []cli.Flag{
-				cli.StringFlag{
-					Name:   "key, k",
-					Usage:  "Google Maps Elevation API 'Key'",
-					Value:  apiKey,
-					EnvVar: "GMAPS_API_KEY",
-				},
-				cli.StringFlag{
-					Name: "input, i",
-					Usage: `
-					Input FILEPATH Format:
+				{
+					Name:  "search",
+					Usage: "Search for places by free text query",
+					Description: `
+					Accepts STDIN or Input FILEPATH [CSV].
+					Outputs STDOUT or Output FILEPATH [CSV].
+					Input STDIN Format:
 						id - [string],
+						query - [string]
+					Output STDOUT Format:
+						...,
+						place_id - [string],
+						name - [string],
+						formatted_address - [string],
 						lat - [float],
-						lng - [float]`,
-					Value: input,
-				},
-				cli.StringFlag{
-					Name: "output, o",
-					Usage: `
-					Output FILEPATH Format:
-						... - ,
-						elevation - [float],
-						resolution - [float],
+						lng - [float],
+						rating - [float],
+						user_ratings_total - [int],
+						types - [string],
+						status - [string],
 						note - [string]`,
-					Value: output,
+					Flags: flagSet([]cli.Flag{
+						cli.StringFlag{
+							Name:   "key, k",
+							Usage:  "Google Maps Places API 'Key'",
+							Value:  apiKey,
+							EnvVar: "GMAPS_API_KEY",
+						},
+						cli.StringFlag{
+							Name: "input, i",
+							Usage: `
+							Input FILEPATH Format:
+								id - [string],
+								query - [string]`,
+							Value: input,
+						},
+						cli.StringFlag{
+							Name:  "query-col",
+							Usage: "Input 'query' Column Name or Index",
+							Value: "1",
+						},
+						cli.StringFlag{
+							Name:  "lat-col",
+							Usage: "Input Location Bias 'lat' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "lng-col",
+							Usage: "Input Location Bias 'lng' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "radius-col",
+							Usage: "Input Location Bias 'radius' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "location",
+							Usage: "Location Bias 'lat,lng' for All Input Records",
+						},
+						cli.IntFlag{
+							Name:  "radius",
+							Usage: "Location Bias 'Radius' in Meters",
+							Value: radius,
+						},
+						cli.IntFlag{
+							Name:  "max-results",
+							Usage: "Maximum Ranked Candidate Results per Input Record [Up to 60]",
+							Value: maxResults,
+						},
+						cli.StringFlag{
+							Name:  "geometry",
+							Usage: "GeoJSON Place 'Geometry' [point, viewport, bounds]",
+							Value: geometry,
+						},
+						cli.StringFlag{
+							Name: "output, o",
+							Usage: `
+							Output FILEPATH Format:
+								...,
+								place_id - [string],
+								name - [string],
+								formatted_address - [string],
+								lat - [float],
+								lng - [float],
+								rating - [float],
+								user_ratings_total - [int],
+								types - [string],
+								status - [string],
+								note - [string]`,
+							Value: output,
+						},
+					}, placeFilterFlags, inputFlags, outputFlags, batchFlags, cacheFlags),
+					Action: func(con *cli.Context) (e error) {
+						// Check input arguments
+						err := CheckArgs(con)
+						ExitOnError(err)
+						// Establish new Google Maps API client connections
+						clt, err := gm.ConnectClient(con)
+						ExitOnError(err)
+						// Authenticate client IP
+						err = gm.CheckClientIP(con, clt)
+						ExitOnError(err)
+						// Open rejects file for invalid input rows
+						rej, err := gm.OpenRejects(con)
+						ExitOnError(err)
+						// Read in query data from csv file
+						rec, err := gm.PlaceSearchReadInput(con, rej)
+						ExitOnError(err)
+						// Request place search results from input CSV file records
+						res, err := gm.PlaceSearchRecords(con, clt, rec)
+						ExitOnError(err)
+						// Write formatted output to CSV file
+						err = gm.PlaceSearchWriteOutput(con, res)
+						ExitOnError(err)
+						// Report rejected input rows
+						return CheckRejects(rej)
+					},
 				},
-			},
-			Action: func(con *cli.Context) (e error) {
-				// Check input arguments
-				err := CheckArgs(con)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
-				// Establish new Google Maps API client connection
-				clt, err := maps.NewClient(maps.WithAPIKey(con.String("key")))
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
-				// Authenticate client IP
-				err = gm.CheckClientIP(con, clt)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
-				// Read in coordinate data from csv file
-				rec, err := gm.ElevationReadInput(con)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
-				// Request elevations from input csv file records
-				res, err := gm.ElevationRecords(con, clt, rec)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
-				// Write formatted output to csv file
-				err = gm.ElevationWriteOutput(con, res)
-				if err != nil {
-					fmt.Println(err)
-					os.Exit(2)
-				}
-				return err
-			},
-		},
-	}
-	sort.Sort(cli.FlagsByName(gmaps.Flags))
-	gmaps.Run(os.Args)
-	os.Exit(1)
+				{
+					Name:  "find",
+					Usage: "Find place IDs by name, address or phone number",
+					Description: `
+					Accepts STDIN or Input FILEPATH [CSV].
+					Outputs STDOUT or Output FILEPATH [CSV].
+					Input STDIN Format:
+						id - [string],
+						query - [string] (text or phone number)
+					Output STDOUT Format:
+						...,
+						place_id - [string],
+						name - [string],
+						formatted_address - [string],
+						lat - [float],
+						lng - [float],
+						types - [string],
+						status - [string],
+						note - [string]`,
+					Flags: flagSet([]cli.Flag{
+						cli.StringFlag{
+							Name:   "key, k",
+							Usage:  "Google Maps Places API 'Key'",
+							Value:  apiKey,
+							EnvVar: "GMAPS_API_KEY",
+						},
+						cli.StringFlag{
+							Name: "input, i",
+							Usage: `
+							Input FILEPATH Format:
+								id - [string],
+								query - [string] (text or phone number)`,
+							Value: input,
+						},
+						cli.StringFlag{
+							Name:  "input-type",
+							Usage: "Query 'Input Type' [textquery, phonenumber]",
+							Value: inputType,
+						},
+						cli.StringFlag{
+							Name:  "language",
+							Usage: "Result 'Language' Code",
+						},
+						cli.StringFlag{
+							Name:  "query-col",
+							Usage: "Input 'query' Column Name or Index",
+							Value: "1",
+						},
+						cli.StringFlag{
+							Name:  "lat-col",
+							Usage: "Input Location Bias 'lat' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "lng-col",
+							Usage: "Input Location Bias 'lng' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "radius-col",
+							Usage: "Input Location Bias 'radius' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "location",
+							Usage: "Location Bias 'lat,lng' for All Input Records",
+						},
+						cli.IntFlag{
+							Name:  "radius",
+							Usage: "Location Bias 'Radius' in Meters [0 for Point Bias]",
+							Value: radius,
+						},
+						cli.IntFlag{
+							Name:  "max-results",
+							Usage: "Maximum Ranked Candidate Results per Input Record",
+							Value: maxResults,
+						},
+						cli.StringFlag{
+							Name:  "geometry",
+							Usage: "GeoJSON Place 'Geometry' [point, viewport, bounds]",
+							Value: geometry,
+						},
+						cli.StringFlag{
+							Name: "output, o",
+							Usage: `
+							Output FILEPATH Format:
+								...,
+								place_id - [string],
+								name - [string],
+								formatted_address - [string],
+								lat - [float],
+								lng - [float],
+								types - [string],
+								status - [string],
+								note - [string]`,
+							Value: output,
+						},
+					}, inputFlags, outputFlags, batchFlags, cacheFlags),
+					Action: func(con *cli.Context) (e error) {
+						// Check input arguments
+						err := CheckArgs(con)
+						ExitOnError(err)
+						// Establish new Google Maps API client connections
+						clt, err := gm.ConnectClient(con)
+						ExitOnError(err)
+						// Authenticate client IP
+						err = gm.CheckClientIP(con, clt)
+						ExitOnError(err)
+						// Open rejects file for invalid input rows
+						rej, err := gm.OpenRejects(con)
+						ExitOnError(err)
+						// Read in find place query data from csv file
+						rec, err := gm.PlaceFindReadInput(con, rej)
+						ExitOnError(err)
+						// Request matched places from input CSV file records
+						res, err := gm.PlaceFindRecords(con, clt, rec)
+						ExitOnError(err)
+						// Write formatted output to CSV file
+						err = gm.PlaceFindWriteOutput(con, res)
+						ExitOnError(err)
+						// Report rejected input rows
+						return CheckRejects(rej)
+					},
+				},
+				{
+					Name:  "autocomplete",
+					Usage: "Predict places from partial input strings",
+					Description: `
+					Accepts STDIN or Input FILEPATH [CSV].
+					Outputs STDOUT or Output FILEPATH [CSV].
+					Input STDIN Format:
+						id - [string],
+						input - [string]
+					Output STDOUT Format:
+						...,
+						session - [string],
+						place_id - [string],
+						description - [string],
+						main_text - [string],
+						secondary_text - [string],
+						types - [string],
+						matched_substrings - [string],
+						status - [string],
+						note - [string]`,
+					Flags: flagSet([]cli.Flag{
+						cli.StringFlag{
+							Name:   "key, k",
+							Usage:  "Google Maps Places API 'Key'",
+							Value:  apiKey,
+							EnvVar: "GMAPS_API_KEY",
+						},
+						cli.StringFlag{
+							Name: "input, i",
+							Usage: `
+							Input FILEPATH Format:
+								id - [string],
+								input - [string]`,
+							Value: input,
+						},
+						cli.StringFlag{
+							Name:  "mode",
+							Usage: "Prediction 'Mode' [place, query]",
+							Value: mode,
+						},
+						cli.StringFlag{
+							Name:  "types",
+							Usage: "Restrict Predictions to Place 'Types' [geocode, address, establishment, (regions), (cities)]",
+						},
+						cli.StringFlag{
+							Name:  "country",
+							Usage: "Restrict Predictions to Comma Separated 'Country' Codes [Up to 5]",
+						},
+						cli.StringFlag{
+							Name:  "language",
+							Usage: "Result 'Language' Code",
+						},
+						cli.BoolFlag{
+							Name:  "strict-bounds",
+							Usage: "Restrict Predictions to the Location Bias Radius",
+						},
+						cli.StringFlag{
+							Name:  "input-col",
+							Usage: "Input 'input' Column Name or Index",
+							Value: "1",
+						},
+						cli.StringFlag{
+							Name:  "session-col",
+							Usage: "Input 'session' Column Name or Index Grouping Rows by Session Token",
+						},
+						cli.StringFlag{
+							Name:  "lat-col",
+							Usage: "Input Location Bias 'lat' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "lng-col",
+							Usage: "Input Location Bias 'lng' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "radius-col",
+							Usage: "Input Location Bias 'radius' Column Name or Index",
+						},
+						cli.StringFlag{
+							Name:  "location",
+							Usage: "Location Bias 'lat,lng' for All Input Records",
+						},
+						cli.IntFlag{
+							Name:  "radius",
+							Usage: "Location Bias 'Radius' in Meters",
+							Value: radius,
+						},
+						cli.IntFlag{
+							Name:  "max-results",
+							Usage: "Maximum Ranked Predictions per Input Record [Up to 5]",
+							Value: maxResults,
+						},
+						cli.StringFlag{
+							Name: "output, o",
+							Usage: `
+							Output FILEPATH Format:
+								...,
+								session - [string],
+								place_id - [string],
+								description - [string],
+								main_text - [string],
+								secondary_text - [string],
+								types - [string],
+								matched_substrings - [string],
+								status - [string],
+								note - [string]`,
+							Value: output,
+						},
+					}, inputFlags, outputFlags, batchFlags, cacheFlags),
+					Action: func(con *cli.Context) (e error) {
+						// Check input arguments
+						err := CheckArgs(con)
+						ExitOnError(err)
+						// Establish new Google Maps API client connections
+						clt, err := gm.ConnectClient(con)
+						ExitOnError(err)
+						// Authenticate client IP
+						err = gm.CheckClientIP(con, clt)
+						ExitOnError(err)
+						// Open rejects file for invalid input rows
+						rej, err := gm.OpenRejects(con)
+						ExitOnError(err)
+						// Read in partial input strings from csv file
+						rec, err := gm.AutocompleteReadInput(con, rej)
+						ExitOnError(err)
+						// Request predictions from input CSV file records
+						res, err := gm.AutocompleteRecords(con, clt, rec)
+						ExitOnError(err)
+						// Write formatted output to CSV file
+						err = gm.AutocompleteWriteOutput(con, res)
+						ExitOnError(err)
+						// Report rejected input rows
+						return CheckRejects(rej)
+					},
+				},
+			},
+		},
+		{
+			Name:  "elevation",
+			Usage: "Google Maps Elevation API Tool",
+			Description: `
+			Accepts STDIN or Input FILEPATH [CSV]. 
+			Outputs STDOUT or Output FILEPATH [CSV].
+			Input STDIN Format: 
+				id - [string],
+				lat - [float],
+				lng - [float]
+			Output STDOUT Format:
+				... ,
+				elevation - [float],
+				resolution - [float],
+				status - [string],
+				note - [string]`,
+			Flags: flagSet([]cli.Flag{
+				cli.StringFlag{
+					Name:   "key, k",
+					Usage:  "Google Maps Elevation API 'Key'",
+					Value:  apiKey,
+					EnvVar: "GMAPS_API_KEY",
+				},
+				cli.StringFlag{
+					Name: "input, i",
+					Usage: `
+					Input FILEPATH Format:
+						id - [string],
+						lat - [float],
+						lng - [float]`,
+					Value: input,
+				},
+				cli.StringFlag{
+					Name:  "lat-col",
+					Usage: "Input 'lat' Column Name or Index",
+					Value: "1",
+				},
+				cli.StringFlag{
+					Name:  "lng-col",
+					Usage: "Input 'lng' Column Name or Index",
+					Value: "2",
+				},
+				cli.StringFlag{
+					Name: "output, o",
+					Usage: `
+					Output FILEPATH Format:
+						... - ,
+						elevation - [float],
+						resolution - [float],
+						status - [string],
+						note - [string]`,
+					Value: output,
+				},
+			}, inputFlags, outputFlags, batchFlags, cacheFlags),
+			Action: func(con *cli.Context) (e error) {
+				// Check input arguments
+				err := CheckArgs(con)
+				ExitOnError(err)
+				// Establish new Google Maps API client connection
+				clt, err := gm.ConnectClient(con)
+				ExitOnError(err)
+				// Authenticate client IP
+				err = gm.CheckClientIP(con, clt)
+				ExitOnError(err)
+				// Open rejects file for invalid input rows
+				rej, err := gm.OpenRejects(con)
+				ExitOnError(err)
+				// Read in coordinate data from csv file
+				rec, err := gm.ElevationReadInput(con, rej)
+				ExitOnError(err)
+				// Request elevations from input csv file records
+				res, err := gm.ElevationRecords(con, clt, rec)
+				ExitOnError(err)
+				// Write formatted output to csv file
+				err = gm.ElevationWriteOutput(con, res)
+				ExitOnError(err)
+				// Report rejected input rows
+				return CheckRejects(rej)
+			},
+		},
+		{
+			Name:  "timezone",
+			Usage: "Google Maps Time Zone API Tool",
+			Description: `
+			Accepts STDIN or Input FILEPATH [CSV].
+			Outputs STDOUT or Output FILEPATH [CSV].
+			Input STDIN Format:
+				id - [string],
+				lat - [float],
+				lng - [float]
+			Output STDOUT Format:
+				... ,
+				timestamp - [int],
+				time_zone_id - [string],
+				time_zone_name - [string],
+				raw_offset - [int],
+				dst_offset - [int],
+				status - [string],
+				note - [string]`,
+			Flags: flagSet([]cli.Flag{
+				cli.StringFlag{
+					Name:   "key, k",
+					Usage:  "Google Maps Time Zone API 'Key'",
+					Value:  apiKey,
+					EnvVar: "GMAPS_API_KEY",
+				},
+				cli.StringFlag{
+					Name: "input, i",
+					Usage: `
+					Input FILEPATH Format:
+						id - [string],
+						lat - [float],
+						lng - [float]`,
+					Value: input,
+				},
+				cli.StringFlag{
+					Name:  "lat-col",
+					Usage: "Input 'lat' Column Name or Index",
+					Value: "1",
+				},
+				cli.StringFlag{
+					Name:  "lng-col",
+					Usage: "Input 'lng' Column Name or Index",
+					Value: "2",
+				},
+				cli.StringFlag{
+					Name:  "timestamp-col",
+					Usage: "Input Per-Row 'timestamp' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "timestamp",
+					Usage: "Default 'Timestamp' [now, unix seconds or RFC 3339]",
+				},
+				cli.StringFlag{
+					Name:  "language",
+					Usage: "Result 'Language' Code",
+				},
+				cli.StringFlag{
+					Name: "output, o",
+					Usage: `
+					Output FILEPATH Format:
+						... ,
+						timestamp - [int],
+						time_zone_id - [string],
+						time_zone_name - [string],
+						raw_offset - [int],
+						dst_offset - [int],
+						status - [string],
+						note - [string]`,
+					Value: output,
+				},
+			}, inputFlags, outputFlags, batchFlags, cacheFlags),
+			Action: func(con *cli.Context) (e error) {
+				// Check input arguments
+				err := CheckArgs(con)
+				ExitOnError(err)
+				// Establish new Google Maps API client connection
+				clt, err := gm.ConnectClient(con)
+				ExitOnError(err)
+				// Authenticate client IP
+				err = gm.CheckClientIP(con, clt)
+				ExitOnError(err)
+				// Open rejects file for invalid input rows
+				rej, err := gm.OpenRejects(con)
+				ExitOnError(err)
+				// Read in coordinate data from csv file
+				rec, err := gm.TimezoneReadInput(con, rej)
+				ExitOnError(err)
+				// Request time zones from input csv file records
+				res, err := gm.TimezoneRecords(con, clt, rec)
+				ExitOnError(err)
+				// Write formatted output to csv file
+				err = gm.TimezoneWriteOutput(con, res)
+				ExitOnError(err)
+				// Report rejected input rows
+				return CheckRejects(rej)
+			},
+		},
+		{
+			Name:  "directions",
+			Usage: "Google Maps Directions API Tool",
+			Description: `
+			Accepts STDIN or Input FILEPATH [CSV].
+			Outputs STDOUT or Output FILEPATH [CSV].
+			Input STDIN Format:
+				id - [string],
+				origin - [string] (address or lat,lng),
+				destination - [string] (address or lat,lng)
+			Output STDOUT Format:
+				... ,
+				mode - [string],
+				distance_m - [int],
+				duration_s - [int],
+				duration_in_traffic_s - [int],
+				summary - [string],
+				polyline - [string],
+				status - [string],
+				note - [string]`,
+			Flags: flagSet([]cli.Flag{
+				cli.StringFlag{
+					Name:   "key, k",
+					Usage:  "Google Maps Directions API 'Key'",
+					Value:  apiKey,
+					EnvVar: "GMAPS_API_KEY",
+				},
+				cli.StringFlag{
+					Name: "input, i",
+					Usage: `
+					Input FILEPATH Format:
+						id - [string],
+						origin - [string] (address or lat,lng),
+						destination - [string] (address or lat,lng)`,
+					Value: input,
+				},
+				cli.StringFlag{
+					Name:  "origin-col",
+					Usage: "Input 'origin' Column Name or Index",
+					Value: "1",
+				},
+				cli.StringFlag{
+					Name:  "destination-col",
+					Usage: "Input 'destination' Column Name or Index",
+					Value: "2",
+				},
+				cli.StringFlag{
+					Name:  "waypoints-col",
+					Usage: "Input Pipe Separated 'waypoints' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "mode-col",
+					Usage: "Input Per-Row 'mode' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "departure-time-col",
+					Usage: "Input Per-Row 'departure_time' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "avoid-col",
+					Usage: "Input Per-Row Pipe Separated 'avoid' Column Name or Index",
+				},
+				cli.StringFlag{
+					Name:  "mode",
+					Usage: "Travel 'Mode' [driving, walking, bicycling, transit]",
+					Value: travelMode,
+				},
+				cli.StringFlag{
+					Name:  "departure-time",
+					Usage: "Departure 'Time' [now, unix seconds or RFC 3339]",
+				},
+				cli.StringFlag{
+					Name:  "avoid",
+					Usage: "Pipe Separated Route Restrictions to 'Avoid' [tolls, highways, ferries]",
+				},
+				cli.StringFlag{
+					Name:  "traffic-model",
+					Usage: "Traffic 'Model' [best_guess, optimistic, pessimistic]",
+				},
+				cli.StringFlag{
+					Name:  "language",
+					Usage: "Result 'Language' Code",
+				},
+				cli.StringFlag{
+					Name:  "region",
+					Usage: "Region Code Biasing Origin and Destination Addresses",
+					Value: region,
+				},
+				cli.StringFlag{
+					Name: "output, o",
+					Usage: `
+					Output FILEPATH Format:
+						... ,
+						mode - [string],
+						distance_m - [int],
+						duration_s - [int],
+						duration_in_traffic_s - [int],
+						summary - [string],
+						polyline - [string],
+						status - [string],
+						note - [string]`,
+					Value: output,
+				},
+			}, inputFlags, outputFlags, batchFlags, cacheFlags),
+			Action: func(con *cli.Context) (e error) {
+				// Check input arguments
+				err := CheckArgs(con)
+				ExitOnError(err)
+				// Establish new Google Maps API client connection
+				clt, err := gm.ConnectClient(con)
+				ExitOnError(err)
+				// Authenticate client IP
+				err = gm.CheckClientIP(con, clt)
+				ExitOnError(err)
+				// Open rejects file for invalid input rows
+				rej, err := gm.OpenRejects(con)
+				ExitOnError(err)
+				// Read in origin and destination data from csv file
+				rec, err := gm.DirectionsReadInput(con, rej)
+				ExitOnError(err)
+				// Request directions from input csv file records
+				res, err := gm.DirectionsRecords(con, clt, rec)
+				ExitOnError(err)
+				// Write formatted output to csv file
+				err = gm.DirectionsWriteOutput(con, res)
+				ExitOnError(err)
+				// Report rejected input rows
+				return CheckRejects(rej)
+			},
+		},
+		{
+			Name:  "matrix",
+			Usage: "Google Maps Distance Matrix API Tool",
+			Description: `
+			Accepts Origins and Destinations FILEPATHS [CSV].
+			Outputs STDOUT or Output FILEPATH [CSV].
+			Origins and Destinations FILEPATH Format:
+				id - [string],
+				location - [string] (address or lat,lng)
+			Output STDOUT Format (long layout):
+				origin_id - [string],
+				destination_id - [string],
+				distance_m - [int],
+				duration_s - [int],
+				duration_in_traffic_s - [int],
+				status - [string],
+				note - [string]
+			Output STDOUT Format (wide layout):
+				origin_id - [string],
+				<destination_id> - [int] ...`,
+			Flags: flagSet([]cli.Flag{
+				cli.StringFlag{
+					Name:   "key, k",
+					Usage:  "Google Maps Distance Matrix API 'Key'",
+					Value:  apiKey,
+					EnvVar: "GMAPS_API_KEY",
+				},
+				cli.StringFlag{
+					Name:  "origins",
+					Usage: "Origins FILEPATH [CSV]",
+				},
+				cli.StringFlag{
+					Name:  "destinations",
+					Usage: "Destinations FILEPATH [CSV]",
+				},
+				cli.StringFlag{
+					Name:  "location-col",
+					Usage: "Input 'location' Column Name or Index",
+					Value: "1",
+				},
+				cli.StringFlag{
+					Name:  "lat-col",
+					Usage: "Input 'lat' Column Name or Index [Overrides location-col]",
+				},
+				cli.StringFlag{
+					Name:  "lng-col",
+					Usage: "Input 'lng' Column Name or Index [Overrides location-col]",
+				},
+				cli.StringFlag{
+					Name:  "mode",
+					Usage: "Travel 'Mode' [driving, walking, bicycling, transit]",
+					Value: travelMode,
+				},
+				cli.StringFlag{
+					Name:  "departure-time",
+					Usage: "Departure 'Time' [now, unix seconds or RFC 3339]",
+				},
+				cli.StringFlag{
+					Name:  "avoid",
+					Usage: "Pipe Separated Route Restrictions to 'Avoid' [tolls, highways, ferries]",
+				},
+				cli.StringFlag{
+					Name:  "traffic-model",
+					Usage: "Traffic 'Model' [best_guess, optimistic, pessimistic]",
+				},
+				cli.StringFlag{
+					Name:  "language",
+					Usage: "Result 'Language' Code",
+				},
+				cli.IntFlag{
+					Name:  "max-elements",
+					Usage: "Maximum Origin x Destination 'Elements' per Request",
+					Value: maxElements,
+				},
+				cli.StringFlag{
+					Name:  "layout",
+					Usage: "Output 'Layout' [long, wide]",
+					Value: layout,
+				},
+				cli.StringFlag{
+					Name:  "value",
+					Usage: "Wide Layout Cell 'Value' [duration, distance]",
+					Value: matrixValue,
+				},
+				cli.StringFlag{
+					Name: "output, o",
+					Usage: `
+					Output FILEPATH Format:
+						origin_id - [string],
+						destination_id - [string],
+						distance_m - [int],
+						duration_s - [int],
+						duration_in_traffic_s - [int],
+						status - [string],
+						note - [string]`,
+					Value: output,
+				},
+			}, inputFlags, outputFlags, batchFlags, cacheFlags),
+			Action: func(con *cli.Context) (e error) {
+				// Check input arguments
+				err := CheckMatrixArgs(con)
+				ExitOnError(err)
+				// Establish new Google Maps API client connection
+				clt, err := gm.ConnectClient(con)
+				ExitOnError(err)
+				// Authenticate client IP
+				err = gm.CheckClientIP(con, clt)
+				ExitOnError(err)
+				// Open rejects file for invalid input rows
+				rej, err := gm.OpenRejects(con)
+				ExitOnError(err)
+				// Read in origin and destination data from csv files
+				org, dst, err := gm.MatrixReadInput(con, rej)
+				ExitOnError(err)
+				// Request distance matrix chunks for all pairs
+				res, err := gm.MatrixRecords(con, clt, org, dst)
+				ExitOnError(err)
+				// Write formatted output to csv file
+				err = gm.MatrixWriteOutput(con, org, dst, res)
+				ExitOnError(err)
+				// Report rejected input rows
+				return CheckRejects(rej)
+			},
+		},
+		{
+			Name:  "roads",
+			Usage: "Google Maps Roads API Tool",
+			Description: `Options for snapping GPS traces to the road network,
+			finding the nearest roads to individual points or looking up
+			posted speed limits from the Google Maps Roads API. Input rows
+			must be contiguous by trace and each trace is split into 100
+			point request windows.`,
+			Subcommands: []cli.Command{
+				{
+					Name:  "snap",
+					Usage: "Snap GPS traces to the most likely roads traveled",
+					Description: `
+					Accepts STDIN or Input FILEPATH [CSV].
+					Outputs STDOUT or Output FILEPATH [CSV].
+					Input STDIN Format:
+						id - [string],
+						trace - [string],
+						lat - [float],
+						lng - [float]
+					Output STDOUT Format:
+						trace_id - [string],
+						point_id - [string],
+						original_index - [int],
+						lat - [float],
+						lng - [float],
+						place_id - [string],
+						status - [string],
+						note - [string]`,
+					Flags: flagSet([]cli.Flag{
+						cli.StringFlag{
+							Name:   "key, k",
+							Usage:  "Google Maps Roads API 'Key'",
+							Value:  apiKey,
+							EnvVar: "GMAPS_API_KEY",
+						},
+						cli.StringFlag{
+							Name: "input, i",
+							Usage: `
+							Input FILEPATH Format:
+								id - [string],
+								trace - [string],
+								lat - [float],
+								lng - [float]`,
+							Value: input,
+						},
+						cli.BoolFlag{
+							Name:  "interpolate",
+							Usage: "Add Interpolated Points Following Road Geometry",
+						},
+						cli.StringFlag{
+							Name: "output, o",
+							Usage: `
+							Output FILEPATH Format:
+								trace_id - [string],
+								point_id - [string],
+								original_index - [int],
+								lat - [float],
+								lng - [float],
+								place_id - [string],
+								status - [string],
+								note - [string]`,
+							Value: output,
+						},
+					}, roadsFlags, inputFlags, outputFlags, batchFlags, cacheFlags),
+					Action: func(con *cli.Context) (e error) {
+						// Check input arguments
+						err := CheckArgs(con)
+						ExitOnError(err)
+						// Establish new Google Maps API client connection
+						clt, err := gm.ConnectClient(con)
+						ExitOnError(err)
+						// Authenticate client IP
+						err = gm.CheckClientIP(con, clt)
+						ExitOnError(err)
+						// Open rejects file for invalid input rows
+						rej, err := gm.OpenRejects(con)
+						ExitOnError(err)
+						// Read in trace points from csv file
+						rec, err := gm.RoadsReadInput(con, rej)
+						ExitOnError(err)
+						// Request snapped points for each trace
+						res, err := gm.RoadsRecords(con, clt, "snap", rec)
+						ExitOnError(err)
+						// Write formatted output to csv file
+						err = gm.RoadsWriteOutput(con, "snap", res)
+						ExitOnError(err)
+						// Report rejected input rows
+						return CheckRejects(rej)
+					},
+				},
+				{
+					Name:  "nearest",
+					Usage: "Find the nearest road segment for each point",
+					Description: `
+					Accepts STDIN or Input FILEPATH [CSV].
+					Outputs STDOUT or Output FILEPATH [CSV].
+					Input STDIN Format:
+						id - [string],
+						trace - [string],
+						lat - [float],
+						lng - [float]
+					Output STDOUT Format:
+						trace_id - [string],
+						point_id - [string],
+						original_index - [int],
+						lat - [float],
+						lng - [float],
+						place_id - [string],
+						status - [string],
+						note - [string]`,
+					Flags: flagSet([]cli.Flag{
+						cli.StringFlag{
+							Name:   "key, k",
+							Usage:  "Google Maps Roads API 'Key'",
+							Value:  apiKey,
+							EnvVar: "GMAPS_API_KEY",
+						},
+						cli.StringFlag{
+							Name: "input, i",
+							Usage: `
+							Input FILEPATH Format:
+								id - [string],
+								trace - [string],
+								lat - [float],
+								lng - [float]`,
+							Value: input,
+						},
+						cli.StringFlag{
+							Name: "output, o",
+							Usage: `
+							Output FILEPATH Format:
+								trace_id - [string],
+								point_id - [string],
+								original_index - [int],
+								lat - [float],
+								lng - [float],
+								place_id - [string],
+								status - [string],
+								note - [string]`,
+							Value: output,
+						},
+					}, roadsFlags, inputFlags, outputFlags, batchFlags, cacheFlags),
+					Action: func(con *cli.Context) (e error) {
+						// Check input arguments
+						err := CheckArgs(con)
+						ExitOnError(err)
+						// Establish new Google Maps API client connection
+						clt, err := gm.ConnectClient(con)
+						ExitOnError(err)
+						// Authenticate client IP
+						err = gm.CheckClientIP(con, clt)
+						ExitOnError(err)
+						// Open rejects file for invalid input rows
+						rej, err := gm.OpenRejects(con)
+						ExitOnError(err)
+						// Read in trace points from csv file
+						rec, err := gm.RoadsReadInput(con, rej)
+						ExitOnError(err)
+						// Request snapped points for each trace
+						res, err := gm.RoadsRecords(con, clt, "nearest", rec)
+						ExitOnError(err)
+						// Write formatted output to csv file
+						err = gm.RoadsWriteOutput(con, "nearest", res)
+						ExitOnError(err)
+						// Report rejected input rows
+						return CheckRejects(rej)
+					},
+				},
+				{
+					Name:  "speedlimits",
+					Usage: "Snap GPS traces and return posted speed limits",
+					Description: `
+					Accepts STDIN or Input FILEPATH [CSV].
+					Outputs STDOUT or Output FILEPATH [CSV].
+					Input STDIN Format:
+						id - [string],
+						trace - [string],
+						lat - [float],
+						lng - [float]
+					Output STDOUT Format:
+						trace_id - [string],
+						point_id - [string],
+						original_index - [int],
+						lat - [float],
+						lng - [float],
+						place_id - [string],
+						speed_limit - [float],
+						units - [string],
+						status - [string],
+						note - [string]`,
+					Flags: flagSet([]cli.Flag{
+						cli.StringFlag{
+							Name:   "key, k",
+							Usage:  "Google Maps Roads API 'Key'",
+							Value:  apiKey,
+							EnvVar: "GMAPS_API_KEY",
+						},
+						cli.StringFlag{
+							Name: "input, i",
+							Usage: `
+							Input FILEPATH Format:
+								id - [string],
+								trace - [string],
+								lat - [float],
+								lng - [float]`,
+							Value: input,
+						},
+						cli.StringFlag{
+							Name:  "units",
+							Usage: "Speed Limit 'Units' [KPH, MPH]",
+							Value: speedUnits,
+						},
+						cli.StringFlag{
+							Name: "output, o",
+							Usage: `
+							Output FILEPATH Format:
+								trace_id - [string],
+								point_id - [string],
+								original_index - [int],
+								lat - [float],
+								lng - [float],
+								place_id - [string],
+								speed_limit - [float],
+								units - [string],
+								status - [string],
+								note - [string]`,
+							Value: output,
+						},
+					}, roadsFlags, inputFlags, outputFlags, batchFlags, cacheFlags),
+					Action: func(con *cli.Context) (e error) {
+						// Check input arguments
+						err := CheckArgs(con)
+						ExitOnError(err)
+						// Establish new Google Maps API client connection
+						clt, err := gm.ConnectClient(con)
+						ExitOnError(err)
+						// Authenticate client IP
+						err = gm.CheckClientIP(con, clt)
+						ExitOnError(err)
+						// Open rejects file for invalid input rows
+						rej, err := gm.OpenRejects(con)
+						ExitOnError(err)
+						// Read in trace points from csv file
+						rec, err := gm.RoadsReadInput(con, rej)
+						ExitOnError(err)
+						// Request snapped points for each trace
+						res, err := gm.RoadsRecords(con, clt, "speedlimits", rec)
+						ExitOnError(err)
+						// Write formatted output to csv file
+						err = gm.RoadsWriteOutput(con, "speedlimits", res)
+						ExitOnError(err)
+						// Report rejected input rows
+						return CheckRejects(rej)
+					},
+				},
+			},
+		},
+		// Response Cache Maintenance Sub-Command
+		{
+			Name:  "cache",
+			Usage: "Response Cache Maintenance Tool",
+			Description: `Options for inspecting, purging and exporting the
+			local cache of Google Maps API responses.`,
+			Subcommands: []cli.Command{
+				{
+					Name:  "stats",
+					Usage: "Print cached response counts by API",
+					Flags: cacheFlags,
+					Action: func(con *cli.Context) (e error) {
+						// Print cache statistics
+						err := gm.CacheStats(con)
+						ExitOnError(err)
+						return err
+					},
+				},
+				{
+					Name:  "purge",
+					Usage: "Delete cached responses",
+					Flags: flagSet([]cli.Flag{
+						cli.BoolFlag{
+							Name:  "expired",
+							Usage: "Only Delete Responses Older than 'cache-ttl'",
+						},
+					}, cacheFlags),
+					Action: func(con *cli.Context) (e error) {
+						// Purge cache entries
+						err := gm.CachePurge(con)
+						ExitOnError(err)
+						return err
+					},
+				},
+				{
+					Name:  "export",
+					Usage: "Export cached responses",
+					Description: `
+					Outputs STDOUT or Output FILEPATH [CSV].
+					Output STDOUT Format:
+						api - [string],
+						request - [json],
+						created - [timestamp],
+						response - [json]`,
+					Flags: flagSet([]cli.Flag{
+						cli.StringFlag{
+							Name:  "output, o",
+							Usage: "Output FILEPATH",
+							Value: output,
+						},
+					}, cacheFlags),
+					Action: func(con *cli.Context) (e error) {
+						// Export cache entries
+						err := gm.CacheExport(con)
+						ExitOnError(err)
+						return err
+					},
+				},
+			},
+		},
+	}
+	sort.Sort(cli.FlagsByName(gmaps.Flags))
+	err := gmaps.Run(os.Args)
+	if err != nil {
+		os.Exit(1)
+	}
 }
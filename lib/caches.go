@@ -0,0 +1,323 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	bolt "go.etcd.io/bbolt"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"strings"
+	"time"
+)
+
+// Cache Bucket Names by API
+const (
+	CacheGeocode        = "geocode"
+	CacheReverseGeocode = "rvgeocode"
+	CacheElevation      = "elevation"
+	CachePlaceNearby    = "nearby"
+	CachePlaceDetail    = "detail"
+	CachePlaceSearch    = "search"
+	CachePlaceFind      = "find"
+	CacheAutocomplete   = "autocomplete"
+	CacheQueryComplete  = "querycomplete"
+	CacheDirections     = "directions"
+	CacheTimezone       = "timezone"
+	CacheMatrix         = "matrix"
+	CacheSnapToRoads    = "snap"
+	CacheNearestRoads   = "nearest"
+	CacheSpeedLimits    = "speedlimits"
+)
+
+// Cache Struct Field Specification
+type Cache struct {
+	db  *bolt.DB
+	ttl time.Duration
+}
+
+// Cache Entry Struct Field Specification
+type cacheEntry struct {
+	Created  time.Time
+	Response json.RawMessage
+}
+
+// Open Response Cache Database on Context Flags
+func openCacheDB(con *cli.Context) (db *bolt.DB, e error) {
+	// Format cache filepath
+	fp, err := CacheFilepath(con.String("cache-file"))
+	if err != nil {
+		return nil, err
+	}
+	// Open database without blocking on a concurrent run
+	return bolt.Open(fp, 0644, &bolt.Options{Timeout: time.Second})
+}
+
+// Open Response Cache When Enabled by Context Flags
+func OpenCache(con *cli.Context) (cch *Cache, e error) {
+	// Skip when caching is disabled
+	if con.Bool("cache") != true || con.Bool("no-cache") {
+		return nil, nil
+	}
+	// Open cache database
+	db, err := openCacheDB(con)
+	if err != nil {
+		return nil, err
+	}
+	cch = &Cache{
+		db:  db,
+		ttl: con.Duration("cache-ttl"),
+	}
+	return cch, nil
+}
+
+// Normalize Free Text Address for Cache Keys
+func NormalizeAddress(address string) (norm string) {
+	return strings.ToLower(strings.Join(strings.Fields(address), " "))
+}
+
+// Get Cached Response for Request Key
+func (c *Cache) Get(api string, key []byte, res interface{}) (ok bool) {
+	// Skip when caching is disabled
+	if c == nil {
+		return false
+	}
+	// Look up cache entry
+	var entry cacheEntry
+	err := c.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(api))
+		if b == nil {
+			return bolt.ErrBucketNotFound
+		}
+		v := b.Get(key)
+		if v == nil {
+			return bolt.ErrBucketNotFound
+		}
+		return json.Unmarshal(v, &entry)
+	})
+	if err != nil {
+		return false
+	}
+	// Check entry expiration
+	if c.ttl > 0 && time.Since(entry.Created) > c.ttl {
+		return false
+	}
+	// Decode cached response
+	return json.Unmarshal(entry.Response, res) == nil
+}
+
+// Put Response in Cache for Request Key
+func (c *Cache) Put(api string, key []byte, res interface{}) (e error) {
+	// Skip when caching is disabled
+	if c == nil {
+		return nil
+	}
+	// Encode cache entry
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	v, err := json.Marshal(cacheEntry{
+		Created:  time.Now(),
+		Response: raw,
+	})
+	if err != nil {
+		return err
+	}
+	// Store cache entry
+	return c.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(api))
+		if err != nil {
+			return err
+		}
+		return b.Put(key, v)
+	})
+}
+
+// Submit API Request Through the Response Cache
+func CachedRequest(con *cli.Context, lmt *RateLimiter, cch *Cache, api string, req interface{}, res interface{}, call func() error) (status string, e error) {
+	// Encode request key
+	key, err := json.Marshal(req)
+	if err != nil {
+		return SubmitRequest(con, lmt, call)
+	}
+	// Return cached response on hit
+	if cch.Get(api, key, res) {
+		return StatusOK, nil
+	}
+	// Submit request and cache successful responses
+	status, err = SubmitRequest(con, lmt, call)
+	if err == nil {
+		if err := cch.Put(api, key, res); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	return status, err
+}
+
+// Close Response Cache Database
+func (c *Cache) Close() (e error) {
+	// Skip when caching is disabled
+	if c == nil {
+		return nil
+	}
+	return c.db.Close()
+}
+
+// Print Response Cache Entry Counts by API
+func CacheStats(con *cli.Context) (e error) {
+	// Open cache database
+	db, err := openCacheDB(con)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	ttl := con.Duration("cache-ttl")
+	// Enter bucket loop
+	err = db.View(func(tx *bolt.Tx) error {
+		fmt.Printf("%-12s %10s %10s\n", "api", "entries", "expired")
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			// Count total and expired entries
+			total, expired := 0, 0
+			err := b.ForEach(func(k, v []byte) error {
+				var entry cacheEntry
+				total++
+				if json.Unmarshal(v, &entry) != nil || (ttl > 0 && time.Since(entry.Created) > ttl) {
+					expired++
+				}
+				return nil
+			})
+			fmt.Printf("%-12s %10d %10d\n", name, total, expired)
+			return err
+		})
+	})
+	if err != nil {
+		return err
+	}
+	// Print database file size
+	info, err := os.Stat(db.Path())
+	if err == nil {
+		fmt.Printf("%-12s %10d bytes\n", "size", info.Size())
+	}
+	return err
+}
+
+// Purge Expired or All Entries from Response Cache
+func CachePurge(con *cli.Context) (e error) {
+	// Open cache database
+	db, err := openCacheDB(con)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	ttl := con.Duration("cache-ttl")
+	// Enter bucket loop
+	purged := 0
+	err = db.Update(func(tx *bolt.Tx) error {
+		var names [][]byte
+		err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			// Collect whole buckets when purging all entries
+			if con.Bool("expired") != true {
+				purged += b.Stats().KeyN
+				names = append(names, append([]byte(nil), name...))
+				return nil
+			}
+			// Delete expired entries
+			c := b.Cursor()
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				var entry cacheEntry
+				if json.Unmarshal(v, &entry) != nil || (ttl > 0 && time.Since(entry.Created) > ttl) {
+					if err := c.Delete(); err != nil {
+						return err
+					}
+					purged++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		// Delete collected buckets
+		for _, name := range names {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	fmt.Printf("Purged %d Cache Entries...\n", purged)
+	return err
+}
+
+// Export Response Cache Entries as CSV
+func CacheExport(con *cli.Context) (e error) {
+	// Open cache database
+	db, err := openCacheDB(con)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	// Switch on output file flag
+	var w *csv.Writer
+	switch con.IsSet("output") {
+	case true:
+		fp := &fileOutput{con.String("output")}
+		f, fw, err := fp.Write()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = fw
+	default:
+		w = csv.NewWriter(os.Stdout)
+	}
+	defer w.Flush()
+	// Write header
+	err = w.Write([]string{
+		"api",
+		"request",
+		"created",
+		"response"})
+	if err != nil {
+		return err
+	}
+	// Enter bucket loop
+	return db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			return b.ForEach(func(k, v []byte) error {
+				var entry cacheEntry
+				if err := json.Unmarshal(v, &entry); err != nil {
+					return err
+				}
+				return w.Write([]string{
+					string(name),
+					string(k),
+					entry.Created.Format(time.RFC3339),
+					string(entry.Response)})
+			})
+		})
+	})
+}
@@ -0,0 +1,290 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Checkpoint Struct Field Specification
+type Checkpoint struct {
+	file *os.File
+	done map[string][]byte
+	err  error
+	mu   sync.Mutex
+}
+
+// Checkpoint Entry Struct Field Specification
+type checkpointEntry struct {
+	Header  *checkpointHeader `json:",omitempty"`
+	Id      string            `json:",omitempty"`
+	Status  string            `json:",omitempty"`
+	Records json.RawMessage   `json:",omitempty"`
+}
+
+// Checkpoint Header Struct Field Specification
+type checkpointHeader struct {
+	Command string
+	Inputs  map[string]string
+	Flags   map[string]string
+}
+
+// Input File Flags Fingerprinted in the Checkpoint Header
+var checkpointInputs = []string{"input", "origins", "destinations"}
+
+// Flags That Do Not Change Request Results and May Differ on Resume
+var checkpointIgnored = map[string]bool{
+	"key":              true,
+	"input":            true,
+	"origins":          true,
+	"destinations":     true,
+	"output":           true,
+	"format":           true,
+	"no-output-header": true,
+	"table":            true,
+	"geometry":         true,
+	"fields":           true,
+	"rejects":          true,
+	"checkpoint":       true,
+	"workers":          true,
+	"ordered":          true,
+	"qps":              true,
+	"daily-limit":      true,
+	"usage-file":       true,
+	"max-retries":      true,
+	"cache":            true,
+	"no-cache":         true,
+	"cache-file":       true,
+	"cache-ttl":        true,
+}
+
+// Build Checkpoint Header from Command Name, Input Files and Request Flags
+func newCheckpointHeader(con *cli.Context) (header *checkpointHeader, e error) {
+	header = &checkpointHeader{
+		Command: con.Command.FullName(),
+		Inputs:  make(map[string]string),
+		Flags:   make(map[string]string),
+	}
+	// Fingerprint input files by absolute path and size
+	for _, name := range checkpointInputs {
+		if con.IsSet(name) != true {
+			continue
+		}
+		fp, err := filepath.Abs(con.String(name))
+		if err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(fp)
+		if err != nil {
+			return nil, err
+		}
+		header.Inputs[name] = fmt.Sprintf("%s (%d bytes)", fp, info.Size())
+	}
+	// Record flags that shape requests
+	for _, name := range con.FlagNames() {
+		if checkpointIgnored[name] != true {
+			header.Flags[name] = con.String(name)
+		}
+	}
+	return header, nil
+}
+
+// Describe the First Difference Between Two Checkpoint Headers
+func (h *checkpointHeader) diff(other *checkpointHeader) (desc string) {
+	// Compare command names
+	if h.Command != other.Command {
+		return fmt.Sprintf("command %q, now %q", h.Command, other.Command)
+	}
+	// Compare input files and flags in name order
+	for _, set := range []struct {
+		kind     string
+		was, now map[string]string
+	}{
+		{"input", h.Inputs, other.Inputs},
+		{"flag", h.Flags, other.Flags},
+	} {
+		var names []string
+		for name := range set.was {
+			names = append(names, name)
+		}
+		for name := range set.now {
+			if _, ok := set.was[name]; ok != true {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if set.was[name] != set.now[name] {
+				return fmt.Sprintf("%s --%s %q, now %q", set.kind, name, set.was[name], set.now[name])
+			}
+		}
+	}
+	return ""
+}
+
+// Open Checkpoint File and Load Previously Finished Records
+func OpenCheckpoint(con *cli.Context) (chk *Checkpoint, e error) {
+	// Skip when checkpoint flag is not set
+	if con.IsSet("checkpoint") != true {
+		return nil, nil
+	}
+	// Describe current command, inputs and flags
+	header, err := newCheckpointHeader(con)
+	if err != nil {
+		return nil, err
+	}
+	// Open checkpoint file for reading and appending
+	fp := con.String("checkpoint")
+	f, err := os.OpenFile(fp, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	chk = &Checkpoint{
+		file: f,
+		done: make(map[string][]byte),
+	}
+	// Enter checkpoint reader loop
+	found := false
+	s := bufio.NewScanner(f)
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for s.Scan() {
+		// Parse checkpoint entry
+		var entry checkpointEntry
+		err = json.Unmarshal(s.Bytes(), &entry)
+		if err != nil {
+			// Skip lines truncated by an interrupted run
+			continue
+		}
+		// Refuse to resume a checkpoint written for another run
+		if found != true {
+			if entry.Header == nil {
+				f.Close()
+				return nil, fmt.Errorf("gmaps: checkpoint %s has no header", fp)
+			}
+			if desc := entry.Header.diff(header); len(desc) != 0 {
+				f.Close()
+				return nil, fmt.Errorf("gmaps: checkpoint %s was written for a different run (%s)", fp, desc)
+			}
+			found = true
+			continue
+		}
+		// Leave transient failures to be requested again
+		if Transient(entry.Status) || entry.Status == StatusDailyLimit {
+			delete(chk.done, entry.Id)
+			continue
+		}
+		chk.done[entry.Id] = append([]byte(nil), entry.Records...)
+	}
+	if err = s.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	// Terminate a partial line left by an interrupted write
+	info, err := f.Stat()
+	if err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		_, err = f.ReadAt(last, info.Size()-1)
+		if err == nil && last[0] != '\n' {
+			_, err = f.Write([]byte{'\n'})
+		}
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	// Start new checkpoints with a header entry
+	if found != true {
+		line, err := json.Marshal(checkpointEntry{Header: header})
+		if err == nil {
+			_, err = f.Write(append(line, '\n'))
+		}
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return chk, nil
+}
+
+// Restore Previously Finished Records for an Input Id from the Checkpoint
+func (c *Checkpoint) Restore(id string, recs interface{}) (ok bool) {
+	// Skip when checkpointing is disabled
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	line, ok := c.done[id]
+	c.mu.Unlock()
+	if ok != true {
+		return false
+	}
+	// Decode checkpoint records
+	return json.Unmarshal(line, recs) == nil
+}
+
+// Append Finished Records for an Input Id to the Checkpoint
+func (c *Checkpoint) Append(id string, status string, recs interface{}) {
+	// Skip when checkpointing is disabled
+	if c == nil {
+		return
+	}
+	// Encode entry as a single line
+	raw, err := json.Marshal(recs)
+	var line []byte
+	if err == nil {
+		line, err = json.Marshal(checkpointEntry{
+			Id:      id,
+			Status:  status,
+			Records: raw,
+		})
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		_, err = c.file.Write(append(line, '\n'))
+	}
+	// Retain first write error
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+}
+
+// Close Checkpoint File and Report Any Write Errors
+func (c *Checkpoint) Close() (e error) {
+	// Skip when checkpointing is disabled
+	if c == nil {
+		return nil
+	}
+	err := c.file.Close()
+	if c.err != nil {
+		return c.err
+	}
+	return err
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Open a Checkpoint for an Input File with Optional Extra Flags
+func openTestCheckpoint(t *testing.T, dir string, flags map[string]string) (chk *Checkpoint, e error) {
+	t.Helper()
+	all := map[string]string{
+		"checkpoint": filepath.Join(dir, "run.chk"),
+		"input":      filepath.Join(dir, "in.csv"),
+	}
+	// Declare every flag on the command so request flags reach the header
+	cmd := cli.Command{Name: "nearby"}
+	for name, value := range flags {
+		all[name] = value
+	}
+	for name := range all {
+		cmd.Flags = append(cmd.Flags, cli.StringFlag{Name: name})
+	}
+	con := testContext(t, all)
+	con.Command = cmd
+	return OpenCheckpoint(con)
+}
+
+func TestCheckpointResume(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		tail     string
+		wantErr  string
+		restored map[string]bool
+	}{
+		{
+			name:     "matching header",
+			input:    "id\n1\n2\n3\n",
+			restored: map[string]bool{"1": true, "2": false, "3": true},
+		},
+		{
+			name:    "mismatched input",
+			input:   "id\n1\n2\n3\n4\n",
+			wantErr: "different run",
+		},
+		{
+			name:     "truncated tail",
+			input:    "id\n1\n2\n3\n",
+			tail:     `{"Id":"4","Sta`,
+			restored: map[string]bool{"1": true, "2": false, "3": true, "4": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			in := filepath.Join(dir, "in.csv")
+			if err := os.WriteFile(in, []byte("id\n1\n2\n3\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			// Write finished, transient and final entries
+			chk, err := openTestCheckpoint(t, dir, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			chk.Append("1", StatusOK, []string{"one"})
+			chk.Append("2", StatusOverQueryLimit, []string{"two"})
+			chk.Append("3", StatusZeroResults, []string{"three"})
+			if err := chk.Close(); err != nil {
+				t.Fatal(err)
+			}
+			// Simulate an interrupted write
+			if len(tt.tail) != 0 {
+				f, err := os.OpenFile(filepath.Join(dir, "run.chk"), os.O_WRONLY|os.O_APPEND, 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.WriteString(tt.tail)
+				f.Close()
+			}
+			// Reopen against the current input
+			if err := os.WriteFile(in, []byte(tt.input), 0644); err != nil {
+				t.Fatal(err)
+			}
+			chk, err = openTestCheckpoint(t, dir, nil)
+			if len(tt.wantErr) != 0 {
+				if err == nil || strings.Contains(err.Error(), tt.wantErr) != true {
+					t.Fatalf("OpenCheckpoint() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Entries appended after a truncated tail survive the next resume
+			chk.Append("4", StatusOK, []string{"four"})
+			if err := chk.Close(); err != nil {
+				t.Fatal(err)
+			}
+			chk, err = openTestCheckpoint(t, dir, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer chk.Close()
+			tt.restored["4"] = true
+			for id, want := range tt.restored {
+				var recs []string
+				if got := chk.Restore(id, &recs); got != want {
+					t.Errorf("Restore(%q) = %v, want %v", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckpointHeaderMismatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{"same flags", map[string]string{"radius": "500"}, false},
+		{"ignored flag changed", map[string]string{"radius": "500", "workers": "8"}, false},
+		{"request flag changed", map[string]string{"radius": "1000"}, true},
+		{"request flag added", map[string]string{"radius": "500", "keyword": "cafe"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "in.csv"), []byte("id\n1\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			chk, err := openTestCheckpoint(t, dir, map[string]string{"radius": "500"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			chk.Close()
+			chk, err = openTestCheckpoint(t, dir, tt.flags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("OpenCheckpoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			chk.Close()
+		})
+	}
+}
+
+func TestCheckpointDuplicateIds(t *testing.T) {
+	tests := []struct {
+		name   string
+		unique string
+		rows   [][]string
+		want   []bool
+	}{
+		{"unique ids", "id-col", [][]string{{"1"}, {"2"}, {"3"}}, []bool{true, true, true}},
+		{"repeated id", "id-col", [][]string{{"1"}, {"2"}, {"1"}}, []bool{true, true, false}},
+		{"checkpoint disabled", "", [][]string{{"1"}, {"1"}}, []bool{true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols := &InputColumns{index: map[string]int{"id-col": 0}}
+			cols.Unique(tt.unique)
+			for i, row := range tt.rows {
+				if got := cols.Claim(row) == nil; got != tt.want[i] {
+					t.Errorf("Claim(%v) accepted = %v, want %v", row, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
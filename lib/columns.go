@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"encoding/csv"
+	"fmt"
+	"gopkg.in/urfave/cli.v1"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Pass-Through Input Column Struct Field Specification
+type Passthrough struct {
+	Header []string `json:"header,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// Input Column Layout Struct Field Specification
+type InputColumns struct {
+	header []string
+	index  map[string]int
+	extra  []int
+	names  []string
+	unique string
+	seen   map[string]bool
+}
+
+// Check Whether the Input Begins with a Header Row
+func HasHeader(con *cli.Context) (header bool) {
+	// Switch on explicit header flags
+	switch {
+	case con.Bool("no-header"):
+		return false
+	case con.Bool("header"):
+		return true
+	default:
+		// Files carry a header row and piped input does not
+		return con.IsSet("input")
+	}
+}
+
+// Resolve Column Flag Value by Header Name or Zero Based Index
+func resolveColumn(con *cli.Context, header []string, flag string, def int) (index int, e error) {
+	// Fall back on default position
+	val := strings.TrimSpace(con.String(flag))
+	if con.IsSet(flag) != true || len(val) == 0 {
+		return def, nil
+	}
+	// Parse numeric index
+	if i, err := strconv.Atoi(val); err == nil {
+		if i < 0 {
+			return -1, fmt.Errorf("gmaps: invalid --%s index %d", flag, i)
+		}
+		return i, nil
+	}
+	// Look up header name
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), val) {
+			return i, nil
+		}
+	}
+	if header == nil {
+		return -1, fmt.Errorf("gmaps: --%s %q requires a header row", flag, val)
+	}
+	return -1, fmt.Errorf("gmaps: --%s column %q not found in header", flag, val)
+}
+
+// Read Header Row and Resolve Input Column Layout
+func ReadColumns(con *cli.Context, r *csv.Reader, defaults map[string]int) (cols *InputColumns, e error) {
+	return readColumns(con, r, defaults, HasHeader(con))
+}
+
+// Resolve Input Column Layout with an Explicit Header Row Switch
+func readColumns(con *cli.Context, r *csv.Reader, defaults map[string]int, hasHeader bool) (cols *InputColumns, e error) {
+	// Allocate column layout
+	cols = &InputColumns{
+		index: make(map[string]int),
+	}
+	// Read header row
+	if hasHeader {
+		header, err := r.Read()
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		cols.header = header
+	}
+	// Resolve column flags
+	used := make(map[int]bool)
+	for flag, def := range defaults {
+		i, err := resolveColumn(con, cols.header, flag, def)
+		if err != nil {
+			return nil, err
+		}
+		cols.index[flag] = i
+		used[i] = true
+	}
+	// Collect pass-through columns from header
+	if con.Bool("passthrough") {
+		for i := range cols.header {
+			if used[i] != true {
+				cols.extra = append(cols.extra, i)
+				cols.names = append(cols.names, cols.header[i])
+			}
+		}
+	}
+	return cols, nil
+}
+
+// Get Mapped Column Value from an Input Row
+func (c *InputColumns) Get(record []string, flag string) (value string) {
+	i, ok := c.index[flag]
+	if !ok || i < 0 || i >= len(record) {
+		return ""
+	}
+	return record[i]
+}
+
+// Check Whether a Mapped Column Is Present in an Input Row
+func (c *InputColumns) Has(record []string, flag string) (ok bool) {
+	i, ok := c.index[flag]
+	return ok && i >= 0 && i < len(record)
+}
+
+// Require Unique Values in a Mapped Column Across Input Rows
+func (c *InputColumns) Unique(flag string) {
+	c.unique = flag
+	c.seen = make(map[string]bool)
+}
+
+// Claim a Row's Unique Column Value and Reject Repeats
+func (c *InputColumns) Claim(record []string) (e error) {
+	// Skip when no unique column is required
+	if len(c.unique) == 0 {
+		return nil
+	}
+	value := c.Get(record, c.unique)
+	if c.seen[value] {
+		return fmt.Errorf("duplicate %s %q with checkpoint enabled", strings.TrimSuffix(c.unique, "-col"), value)
+	}
+	c.seen[value] = true
+	return nil
+}
+
+// Get Pass-Through Column Values from an Input Row
+func (c *InputColumns) Extra(con *cli.Context, record []string) (extra Passthrough) {
+	// Skip when pass-through is disabled
+	if con.Bool("passthrough") != true {
+		return extra
+	}
+	// Name unmapped columns positionally without a header
+	if c.header == nil && c.names == nil {
+		used := make(map[int]bool)
+		for _, i := range c.index {
+			used[i] = true
+		}
+		for i := range record {
+			if used[i] != true {
+				c.extra = append(c.extra, i)
+				c.names = append(c.names, "col_"+strconv.Itoa(i))
+			}
+		}
+	}
+	// Copy pass-through values
+	extra.Header = c.names
+	extra.Values = make([]string, len(c.extra))
+	for j, i := range c.extra {
+		if i < len(record) {
+			extra.Values[j] = record[i]
+		}
+	}
+	return extra
+}
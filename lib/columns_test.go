@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"testing"
+)
+
+func TestHasHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		want  bool
+	}{
+		{"piped input", map[string]string{}, false},
+		{"input file", map[string]string{"input": "in.csv"}, true},
+		{"piped with header", map[string]string{"header": "true"}, true},
+		{"file without header", map[string]string{"input": "in.csv", "no-header": "true"}, false},
+		{"no-header wins", map[string]string{"header": "true", "no-header": "true"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasHeader(testContext(t, tt.flags)); got != tt.want {
+				t.Errorf("HasHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveColumn(t *testing.T) {
+	header := []string{"ID", " Address ", "lat", "lng"}
+	tests := []struct {
+		name    string
+		header  []string
+		flags   map[string]string
+		def     int
+		want    int
+		wantErr bool
+	}{
+		{"default position", header, map[string]string{}, 1, 1, false},
+		{"unmapped default", header, map[string]string{}, -1, -1, false},
+		{"empty value keeps default", header, map[string]string{"address-col": " "}, 1, 1, false},
+		{"numeric index", header, map[string]string{"address-col": "3"}, 1, 3, false},
+		{"index without header", nil, map[string]string{"address-col": "2"}, 1, 2, false},
+		{"negative index", header, map[string]string{"address-col": "-1"}, 1, -1, true},
+		{"header name", header, map[string]string{"address-col": "lat"}, 1, 2, false},
+		{"header name case and space", header, map[string]string{"address-col": "address"}, 0, 1, false},
+		{"missing header name", header, map[string]string{"address-col": "street"}, 1, -1, true},
+		{"name without header", nil, map[string]string{"address-col": "address"}, 1, -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveColumn(testContext(t, tt.flags), tt.header, "address-col", tt.def)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveColumn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveColumn() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,13 +27,21 @@ import (
 	"golang.org/x/net/context"
 	"googlemaps.github.io/maps"
 	"gopkg.in/urfave/cli.v1"
+	"os"
+	"time"
 )
 
 // Establish Client API Connection
 func ConnectClient(con *cli.Context) (clt *maps.Client, e error) {
-    key := con.String("key")
-    clt, err := maps.NewClient(maps.WithAPIKey(key))
-    return clt, err
+	// Allocate client options
+	key := con.String("key")
+	opts := []maps.ClientOption{maps.WithAPIKey(key)}
+	// Set client side rate limit on optional flag
+	if con.Int("qps") > 0 {
+		opts = append(opts, maps.WithRateLimit(con.Int("qps")))
+	}
+	clt, err := maps.NewClient(opts...)
+	return clt, err
 }
 
 // Check API Connection Against Current IP
@@ -44,6 +52,18 @@ func CheckClientIP(con *cli.Context, clt *maps.Client) (e error) {
 		success string = "Client IP Authenticated..."
 		failure string = "Client IP Denied..."
 	)
+	// Count test request against the daily query budget
+	lmt, err := NewRateLimiter(con)
+	if err != nil {
+		return err
+	}
+	err = lmt.Wait()
+	if cerr := lmt.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
 	// Switch on input command name
 	switch con.Command.Name {
 	case "geocode":
@@ -78,6 +98,19 @@ func CheckClientIP(con *cli.Context, clt *maps.Client) (e error) {
 		}
 		// Submit test request
 		_, err = clt.Elevation(context.Background(), &req)
+	case "timezone":
+		// Allocate empty time zone request object
+		var req maps.TimezoneRequest
+		// Build test request
+		req = maps.TimezoneRequest{
+			Location: &maps.LatLng{
+				Lat: 39.73915360,
+				Lng: -104.9847034,
+			},
+			Timestamp: time.Now(),
+		}
+		// Submit test request
+		_, err = clt.Timezone(context.Background(), &req)
 	case "nearby":
 		// Allocate empty places request object
 		var req maps.NearbySearchRequest
@@ -88,12 +121,92 @@ func CheckClientIP(con *cli.Context, clt *maps.Client) (e error) {
 		}
 		// Submit test request
 		_, err = clt.NearbySearch(context.Background(), &req)
+	case "detail":
+		// Allocate empty place detail request object
+		var req maps.PlaceDetailsRequest
+		// Build test request
+		req = maps.PlaceDetailsRequest{
+			PlaceID: "ChIJ2eUgeAK6j4ARbn5u_wAGqWA",
+		}
+		// Submit test request
+		_, err = clt.PlaceDetails(context.Background(), &req)
+	case "search":
+		// Allocate empty text search request object
+		var req maps.TextSearchRequest
+		// Build test request
+		req = maps.TextSearchRequest{
+			Query: "pharmacy near 94110",
+		}
+		// Submit test request
+		_, err = clt.TextSearch(context.Background(), &req)
+	case "find":
+		// Allocate empty find place request object
+		var req maps.FindPlaceFromTextRequest
+		// Build test request
+		req = maps.FindPlaceFromTextRequest{
+			Input:     "Googleplex",
+			InputType: maps.FindPlaceFromTextInputTypeTextQuery,
+		}
+		// Submit test request
+		_, err = clt.FindPlaceFromText(context.Background(), &req)
+	case "autocomplete":
+		// Allocate empty autocomplete request object
+		var req maps.PlaceAutocompleteRequest
+		// Build test request
+		req = maps.PlaceAutocompleteRequest{
+			Input: "1600 Amphitheatre",
+		}
+		// Submit test request
+		_, err = clt.PlaceAutocomplete(context.Background(), &req)
+	case "directions":
+		// Allocate empty directions request object
+		var req maps.DirectionsRequest
+		// Build test request
+		req = maps.DirectionsRequest{
+			Origin:      "Denver, CO",
+			Destination: "Boulder, CO",
+		}
+		// Submit test request
+		_, _, err = clt.Directions(context.Background(), &req)
+	case "snap", "speedlimits":
+		// Allocate empty snap to roads request object
+		var req maps.SnapToRoadRequest
+		// Build test request
+		req = maps.SnapToRoadRequest{
+			Path: []maps.LatLng{
+				{Lat: 39.7391536, Lng: -104.9847034},
+				{Lat: 39.7398261, Lng: -104.9847021},
+			},
+		}
+		// Submit test request
+		_, err = clt.SnapToRoad(context.Background(), &req)
+	case "nearest":
+		// Allocate empty nearest roads request object
+		var req maps.NearestRoadsRequest
+		// Build test request
+		req = maps.NearestRoadsRequest{
+			Points: []maps.LatLng{
+				{Lat: 39.7391536, Lng: -104.9847034},
+			},
+		}
+		// Submit test request
+		_, err = clt.NearestRoads(context.Background(), &req)
+	case "matrix":
+		// Allocate empty distance matrix request object
+		var req maps.DistanceMatrixRequest
+		// Build test request
+		req = maps.DistanceMatrixRequest{
+			Origins:      []string{"Denver, CO"},
+			Destinations: []string{"Boulder, CO"},
+		}
+		// Submit test request
+		_, err = clt.DistanceMatrix(context.Background(), &req)
 	}
-	// Print success message to console
+	// Print status message to stderr
 	if err == nil {
-		fmt.Println(success)
+		fmt.Fprintln(os.Stderr, success)
 	} else {
-		fmt.Println(failure)
+		fmt.Fprintln(os.Stderr, failure)
 	}
 	return err
 }
@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"fmt"
+	"gopkg.in/urfave/cli.v1"
+	"strconv"
+	"strings"
+)
+
+// Geocode Output Field Struct Field Specification
+type GeocodeField struct {
+	Name   string
+	Format func(rec *GeocodeRecord) string
+}
+
+// Geocode Output Fields in Full Output Order
+var GeocodeFields = []GeocodeField{
+	{"id", func(rec *GeocodeRecord) string { return rec.Id }},
+	{"rank", func(rec *GeocodeRecord) string { return strconv.Itoa(rec.Rank) }},
+	{"address", func(rec *GeocodeRecord) string { return rec.Address }},
+	{"lat", func(rec *GeocodeRecord) string { return strconv.FormatFloat(rec.Lat, 'f', -1, 64) }},
+	{"lng", func(rec *GeocodeRecord) string { return strconv.FormatFloat(rec.Lng, 'f', -1, 64) }},
+	{"formatted_address", func(rec *GeocodeRecord) string { return rec.Detail.FormattedAddress }},
+	{"place_id", func(rec *GeocodeRecord) string { return rec.Detail.PlaceId }},
+	{"location_type", func(rec *GeocodeRecord) string { return rec.Detail.LocationType }},
+	{"partial_match", func(rec *GeocodeRecord) string { return strconv.FormatBool(rec.Detail.PartialMatch) }},
+	{"types", func(rec *GeocodeRecord) string { return strings.Join(rec.Detail.Types, "|") }},
+	{"street_number", func(rec *GeocodeRecord) string { return rec.Detail.StreetNumber }},
+	{"route", func(rec *GeocodeRecord) string { return rec.Detail.Route }},
+	{"locality", func(rec *GeocodeRecord) string { return rec.Detail.Locality }},
+	{"county", func(rec *GeocodeRecord) string { return rec.Detail.County }},
+	{"state", func(rec *GeocodeRecord) string { return rec.Detail.State }},
+	{"postal_code", func(rec *GeocodeRecord) string { return rec.Detail.PostalCode }},
+	{"country", func(rec *GeocodeRecord) string { return rec.Detail.Country }},
+	{"status", func(rec *GeocodeRecord) string { return rec.Status }},
+	{"note", func(rec *GeocodeRecord) string { return rec.Note }},
+}
+
+// Default Geocoding Output Fields
+var GeocodeDefaultFields = []string{
+	"id",
+	"address",
+	"lat",
+	"lng",
+	"status",
+	"note",
+}
+
+// Default Reverse Geocoding Output Fields
+var ReverseGeocodeDefaultFields = []string{
+	"id",
+	"lat",
+	"lng",
+	"address",
+	"status",
+	"note",
+}
+
+// Select Geocode Output Fields from the Optional Fields Flag
+func SelectGeocodeFields(con *cli.Context, defaults []string) (fields []GeocodeField, e error) {
+	// Index fields by name
+	index := make(map[string]GeocodeField)
+	for _, f := range GeocodeFields {
+		index[f.Name] = f
+	}
+	// Include candidate rank when multiple results are requested
+	if MaxResults(con) > 1 {
+		defaults = append([]string{defaults[0], "rank"}, defaults[1:]...)
+	}
+	// Expand field name list
+	var names []string
+	if len(con.String("fields")) == 0 {
+		names = defaults
+	} else {
+		for _, name := range strings.Split(con.String("fields"), ",") {
+			switch name = strings.ToLower(strings.TrimSpace(name)); name {
+			case "":
+				continue
+			case "default":
+				names = append(names, defaults...)
+			case "all":
+				for _, f := range GeocodeFields {
+					names = append(names, f.Name)
+				}
+			default:
+				names = append(names, name)
+			}
+		}
+	}
+	// Resolve field names
+	for _, name := range names {
+		f, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("gmaps: unknown output field %q", name)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// Format Header Row for Selected Geocode Fields
+func GeocodeHeader(fields []GeocodeField) (header []string) {
+	for _, f := range fields {
+		header = append(header, f.Name)
+	}
+	return header
+}
+
+// Format Record Row for Selected Geocode Fields
+func GeocodeRow(fields []GeocodeField, rec *GeocodeRecord) (row []string) {
+	for _, f := range fields {
+		row = append(row, f.Format(rec))
+	}
+	return row
+}
@@ -23,8 +23,12 @@ SOFTWARE.
 package gmaps
 
 import (
+	"fmt"
 	"googlemaps.github.io/maps"
 	"gopkg.in/urfave/cli.v1"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Format Geocode Record for API Request
@@ -42,6 +46,23 @@ func GeocodeFormatRequest(con *cli.Context, rec *GeocodeRecord) (request maps.Ge
 			Region:  con.String("region"),
 		}
 	}
+	// Set component filters from structured address parts
+	components := make(map[maps.Component]string)
+	if len(rec.PostalCode) != 0 {
+		components[maps.ComponentPostalCode] = rec.PostalCode
+	}
+	if len(rec.Country) != 0 {
+		components[maps.ComponentCountry] = rec.Country
+	}
+	if len(rec.State) != 0 {
+		components[maps.ComponentAdministrativeArea] = rec.State
+	}
+	if len(rec.City) != 0 {
+		components[maps.ComponentLocality] = rec.City
+	}
+	if len(components) != 0 {
+		req.Components = components
+	}
 	return req
 }
 
@@ -79,6 +100,40 @@ func ElevationFormatRequest(con *cli.Context, rec *ElevationRecord) (request map
 	return req
 }
 
+// Parse Time Zone Timestamp as Unix Seconds or RFC 3339
+func ParseTimestamp(value string) (timestamp int64, e error) {
+	// Default to the current hour so repeated runs share cached responses
+	value = strings.TrimSpace(value)
+	if len(value) == 0 || value == "now" {
+		return time.Now().Truncate(time.Hour).Unix(), nil
+	}
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return unix, nil
+	}
+	// Convert timestamps to unix seconds
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q", value)
+	}
+	return t.Unix(), nil
+}
+
+// Format Time Zone Record for API Request
+func TimezoneFormatRequest(con *cli.Context, rec *TimezoneRecord) (request maps.TimezoneRequest) {
+	// Allocated empty request
+	var req maps.TimezoneRequest
+	// Set request format
+	req = maps.TimezoneRequest{
+		Location: &maps.LatLng{
+			Lat: rec.Lat,
+			Lng: rec.Lng,
+		},
+		Timestamp: time.Unix(rec.Timestamp, 0).UTC(),
+		Language:  con.String("language"),
+	}
+	return req
+}
+
 // Format Place Nearby Record for API Request
 func PlaceNearbyFormatRequest(con *cli.Context, rec *PlaceRecord) (request maps.NearbySearchRequest) {
 	// Allocated empty request
@@ -87,17 +142,346 @@ func PlaceNearbyFormatRequest(con *cli.Context, rec *PlaceRecord) (request maps.
 	req = maps.NearbySearchRequest{
 		Location: &maps.LatLng{rec.Lat, rec.Lng},
 		Radius:   rec.Radius,
+		Keyword:  rec.Filter.Keyword,
+		Name:     rec.Filter.Name,
+		Type:     maps.PlaceType(rec.Filter.Type),
+		Language: rec.Filter.Language,
+		MinPrice: maps.PriceLevel(rec.Filter.MinPrice),
+		MaxPrice: maps.PriceLevel(rec.Filter.MaxPrice),
+		OpenNow:  rec.Filter.OpenNow,
+		RankBy:   maps.RankBy(rec.Filter.RankBy),
+	}
+	// Drop radius when ranking by distance
+	if req.RankBy == maps.RankByDistance {
+		req.Radius = 0
 	}
 	return req
 }
 
 // Format Place Detail Record for API Request
 func PlaceDetailFormatRequest(con *cli.Context, rec *PlaceRecord) (request maps.PlaceDetailsRequest) {
-    // Allocated empty request
-    var req maps.PlaceDetailsRequest
-    // Set request format
-    req = maps.PlaceDetailsRequest{
-        PlaceID: rec.Id,
-    }
-    return req
+	// Allocated empty request
+	var req maps.PlaceDetailsRequest
+	// Set request format
+	req = maps.PlaceDetailsRequest{
+		PlaceID: rec.PlaceId,
+	}
+	return req
+}
+
+// Validate Place Search Filters
+func CheckPlaceFilter(filter PlaceFilter) (e error) {
+	// Validate place type
+	if len(filter.Type) != 0 {
+		_, err := maps.ParsePlaceType(filter.Type)
+		if err != nil {
+			return fmt.Errorf("invalid place type %q", filter.Type)
+		}
+	}
+	// Validate price levels
+	for _, price := range []string{filter.MinPrice, filter.MaxPrice} {
+		switch price {
+		case "", "0", "1", "2", "3", "4":
+		default:
+			return fmt.Errorf("invalid price level %q", price)
+		}
+	}
+	// Validate result ranking
+	switch filter.RankBy {
+	case "", string(maps.RankByProminence):
+	case string(maps.RankByDistance):
+		if len(filter.Keyword) == 0 && len(filter.Name) == 0 && len(filter.Type) == 0 {
+			return fmt.Errorf("rank by distance requires a keyword, name or type")
+		}
+	default:
+		return fmt.Errorf("invalid rank by %q", filter.RankBy)
+	}
+	return nil
+}
+
+// Validate Place Search Filter Flags
+func CheckPlaceFlags(con *cli.Context) (e error) {
+	// Validate filters
+	err := CheckPlaceFilter(PlaceFilter{
+		Type:     con.String("type"),
+		MinPrice: con.String("min-price"),
+		MaxPrice: con.String("max-price"),
+	})
+	if err != nil {
+		return fmt.Errorf("gmaps: %v", err)
+	}
+	// Validate location bias
+	if len(con.String("location")) != 0 {
+		_, err := maps.ParseLatLng(con.String("location"))
+		if err != nil {
+			return fmt.Errorf("gmaps: invalid location %q", con.String("location"))
+		}
+	}
+	return nil
+}
+
+// Format Place Search Record for API Request
+func PlaceSearchFormatRequest(con *cli.Context, rec *PlaceRecord) (request maps.TextSearchRequest) {
+	// Allocated empty request
+	var req maps.TextSearchRequest
+	// Set request format
+	req = maps.TextSearchRequest{
+		Query:    rec.Query,
+		Language: con.String("language"),
+		MinPrice: maps.PriceLevel(con.String("min-price")),
+		MaxPrice: maps.PriceLevel(con.String("max-price")),
+		OpenNow:  con.Bool("open-now"),
+		Type:     maps.PlaceType(con.String("type")),
+	}
+	// Set location bias
+	if rec.Lat != 0 || rec.Lng != 0 {
+		req.Location = &maps.LatLng{Lat: rec.Lat, Lng: rec.Lng}
+		req.Radius = rec.Radius
+	}
+	return req
+}
+
+// Format Find Place Record for API Request
+func PlaceFindFormatRequest(con *cli.Context, rec *PlaceRecord) (request maps.FindPlaceFromTextRequest) {
+	// Allocated empty request
+	var req maps.FindPlaceFromTextRequest
+	// Set request format
+	req = maps.FindPlaceFromTextRequest{
+		Input:     rec.Query,
+		InputType: maps.FindPlaceFromTextInputType(strings.ToLower(con.String("input-type"))),
+		Language:  con.String("language"),
+		Fields: []maps.PlaceSearchFieldMask{
+			maps.PlaceSearchFieldMaskPlaceID,
+			maps.PlaceSearchFieldMaskName,
+			maps.PlaceSearchFieldMaskFormattedAddress,
+			maps.PlaceSearchFieldMaskGeometry,
+			maps.PlaceSearchFieldMaskTypes,
+		},
+	}
+	// Set point or circular location bias
+	if rec.Lat != 0 || rec.Lng != 0 {
+		location := &maps.LatLng{Lat: rec.Lat, Lng: rec.Lng}
+		if rec.Radius > 0 {
+			req.LocationBias = maps.FindPlaceFromTextLocationBiasCircular
+			req.LocationBiasCenter = location
+			req.LocationBiasRadius = int(rec.Radius)
+		} else {
+			req.LocationBias = maps.FindPlaceFromTextLocationBiasPoint
+			req.LocationBiasPoint = location
+		}
+	}
+	return req
+}
+
+// Validate Place Autocomplete Flags
+func CheckAutocompleteFlags(con *cli.Context) (e error) {
+	// Validate prediction mode
+	switch con.String("mode") {
+	case "place", "query":
+	default:
+		return fmt.Errorf("gmaps: unknown autocomplete mode %q", con.String("mode"))
+	}
+	// Validate place types
+	if len(con.String("types")) != 0 {
+		_, err := maps.ParseAutocompletePlaceType(con.String("types"))
+		if err != nil {
+			return fmt.Errorf("gmaps: invalid autocomplete types %q", con.String("types"))
+		}
+	}
+	// Validate country filter
+	if countries := AutocompleteCountries(con); len(countries) > 5 {
+		return fmt.Errorf("gmaps: at most 5 autocomplete countries allowed")
+	}
+	// Validate location bias
+	if len(con.String("location")) != 0 {
+		_, err := maps.ParseLatLng(con.String("location"))
+		if err != nil {
+			return fmt.Errorf("gmaps: invalid location %q", con.String("location"))
+		}
+	}
+	return nil
+}
+
+// Parse Comma Separated Autocomplete Country Codes
+func AutocompleteCountries(con *cli.Context) (countries []string) {
+	for _, country := range strings.Split(con.String("country"), ",") {
+		if country = strings.ToLower(strings.TrimSpace(country)); len(country) != 0 {
+			countries = append(countries, country)
+		}
+	}
+	return countries
+}
+
+// Format Place Autocomplete Record for API Request
+func AutocompleteFormatRequest(con *cli.Context, rec *AutocompleteRecord) (request maps.PlaceAutocompleteRequest) {
+	// Allocated empty request
+	var req maps.PlaceAutocompleteRequest
+	// Set request format
+	req = maps.PlaceAutocompleteRequest{
+		Input:        rec.Input,
+		Language:     con.String("language"),
+		Types:        maps.AutocompletePlaceType(con.String("types")),
+		StrictBounds: con.Bool("strict-bounds"),
+		SessionToken: rec.Token,
+	}
+	// Set country component filter
+	if countries := AutocompleteCountries(con); len(countries) != 0 {
+		req.Components = map[maps.Component][]string{
+			maps.ComponentCountry: countries,
+		}
+	}
+	// Set location bias
+	if rec.Lat != 0 || rec.Lng != 0 {
+		req.Location = &maps.LatLng{Lat: rec.Lat, Lng: rec.Lng}
+		req.Radius = rec.Radius
+	}
+	return req
+}
+
+// Format Query Autocomplete Record for API Request
+func QueryAutocompleteFormatRequest(con *cli.Context, rec *AutocompleteRecord) (request maps.QueryAutocompleteRequest) {
+	// Allocated empty request
+	var req maps.QueryAutocompleteRequest
+	// Set request format
+	req = maps.QueryAutocompleteRequest{
+		Input:    rec.Input,
+		Language: con.String("language"),
+	}
+	// Set location bias
+	if rec.Lat != 0 || rec.Lng != 0 {
+		req.Location = &maps.LatLng{Lat: rec.Lat, Lng: rec.Lng}
+		req.Radius = rec.Radius
+	}
+	return req
+}
+
+// Validate Travel Mode and Route Restrictions
+func CheckTravelOptions(mode string, avoids []string) (e error) {
+	// Validate travel mode
+	switch maps.Mode(mode) {
+	case "", maps.TravelModeDriving, maps.TravelModeWalking, maps.TravelModeBicycling, maps.TravelModeTransit:
+	default:
+		return fmt.Errorf("invalid travel mode %q", mode)
+	}
+	// Validate route restrictions
+	for _, avoid := range avoids {
+		switch maps.Avoid(avoid) {
+		case maps.AvoidTolls, maps.AvoidHighways, maps.AvoidFerries:
+		default:
+			return fmt.Errorf("invalid avoid option %q", avoid)
+		}
+	}
+	return nil
+}
+
+// Validate Travel Option Flags
+func CheckTravelFlags(con *cli.Context) (e error) {
+	// Validate traffic model
+	switch maps.TrafficModel(con.String("traffic-model")) {
+	case "", maps.TrafficModelBestGuess, maps.TrafficModelOptimistic, maps.TrafficModelPessimistic:
+	default:
+		return fmt.Errorf("gmaps: invalid traffic model %q", con.String("traffic-model"))
+	}
+	// Validate travel mode, route restrictions and departure time
+	err := CheckTravelOptions(strings.ToLower(con.String("mode")), splitList(strings.ToLower(con.String("avoid"))))
+	if err == nil {
+		_, err = ParseDepartureTime(con.String("departure-time"))
+	}
+	if err != nil {
+		return fmt.Errorf("gmaps: %v", err)
+	}
+	return nil
+}
+
+// Validate Roads Window Overlap and Speed Limit Unit Flags
+func CheckRoadsFlags(con *cli.Context) (e error) {
+	// Validate window overlap
+	overlap := con.Int("overlap")
+	if overlap < 0 || overlap >= roadsWindowMax {
+		return fmt.Errorf("gmaps: overlap must be between 0 and %d", roadsWindowMax-1)
+	}
+	// Validate speed limit units
+	switch strings.ToUpper(con.String("units")) {
+	case "", maps.SpeedLimitKPH, maps.SpeedLimitMPH:
+	default:
+		return fmt.Errorf("gmaps: invalid speed limit units %q", con.String("units"))
+	}
+	return nil
+}
+
+// Split a Roads Trace into Overlapping Windows and Return Start Offsets
+func RoadsWindows(points int, overlap int) (starts []int) {
+	// Step forward by the window size less the overlap
+	step := roadsWindowMax - overlap
+	for start := 0; start < points; start += step {
+		starts = append(starts, start)
+		if start+roadsWindowMax >= points {
+			break
+		}
+	}
+	return starts
+}
+
+// Parse Departure Time as Now, Unix Seconds or RFC 3339 Timestamp
+func ParseDepartureTime(value string) (departure string, e error) {
+	// Pass through now and unix seconds
+	value = strings.TrimSpace(value)
+	if len(value) == 0 || value == "now" {
+		return value, nil
+	}
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return value, nil
+	}
+	// Convert timestamps to unix seconds
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", fmt.Errorf("invalid departure time %q", value)
+	}
+	return strconv.FormatInt(t.Unix(), 10), nil
+}
+
+// Format Directions Record for API Request
+func DirectionsFormatRequest(con *cli.Context, rec *DirectionsRecord) (request maps.DirectionsRequest) {
+	// Allocated empty request
+	var req maps.DirectionsRequest
+	// Set request format
+	req = maps.DirectionsRequest{
+		Origin:        rec.Origin,
+		Destination:   rec.Destination,
+		Waypoints:     rec.Waypoints,
+		Mode:          maps.Mode(rec.Mode),
+		DepartureTime: rec.DepartureTime,
+		Language:      con.String("language"),
+		Region:        con.String("region"),
+		TrafficModel:  maps.TrafficModel(con.String("traffic-model")),
+	}
+	// Set route restrictions
+	for _, avoid := range rec.Avoid {
+		req.Avoid = append(req.Avoid, maps.Avoid(avoid))
+	}
+	return req
+}
+
+// Format Distance Matrix Chunk for API Request
+func MatrixFormatRequest(con *cli.Context, origins []*MatrixPoint, destinations []*MatrixPoint) (request maps.DistanceMatrixRequest) {
+	// Allocated empty request
+	var req maps.DistanceMatrixRequest
+	// Set request format
+	req = maps.DistanceMatrixRequest{
+		Mode:          maps.Mode(strings.ToLower(con.String("mode"))),
+		Language:      con.String("language"),
+		Avoid:         maps.Avoid(strings.Join(splitList(strings.ToLower(con.String("avoid"))), "|")),
+		TrafficModel:  maps.TrafficModel(con.String("traffic-model")),
+		DepartureTime: con.String("departure-time"),
+	}
+	// Convert departure timestamps to unix seconds
+	req.DepartureTime, _ = ParseDepartureTime(req.DepartureTime)
+	// Set chunk locations
+	for _, o := range origins {
+		req.Origins = append(req.Origins, o.Location)
+	}
+	for _, d := range destinations {
+		req.Destinations = append(req.Destinations, d.Location)
+	}
+	return req
 }
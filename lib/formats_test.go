@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoadsWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		points  int
+		overlap int
+		want    []int
+	}{
+		{"empty trace", 0, 10, nil},
+		{"short trace", 5, 10, []int{0}},
+		{"exactly one window", 100, 10, []int{0}},
+		{"one point over", 101, 10, []int{0, 90}},
+		{"long trace", 250, 10, []int{0, 90, 180}},
+		{"no overlap", 200, 0, []int{0, 100}},
+		{"no overlap remainder", 201, 0, []int{0, 100, 200}},
+		{"large overlap", 120, 90, []int{0, 10, 20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RoadsWindows(tt.points, tt.overlap)
+			if reflect.DeepEqual(got, tt.want) != true {
+				t.Fatalf("RoadsWindows(%d, %d) = %v, want %v", tt.points, tt.overlap, got, tt.want)
+			}
+			// Windows share the overlap and the last window reaches the end
+			for i := 1; i < len(got); i++ {
+				if got[i-1]+roadsWindowMax-got[i] != tt.overlap {
+					t.Errorf("windows at %d and %d do not overlap by %d", got[i-1], got[i], tt.overlap)
+				}
+			}
+			if len(got) != 0 && got[len(got)-1]+roadsWindowMax < tt.points {
+				t.Errorf("last window at %d does not reach point %d", got[len(got)-1], tt.points-1)
+			}
+		})
+	}
+}
+
+func TestCheckOutput(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]string
+		ok    bool
+	}{
+		{"default csv", map[string]string{}, true},
+		{"format flag", map[string]string{"format": "JSONL"}, true},
+		{"unknown format", map[string]string{"format": "xml"}, false},
+		{"extension format", map[string]string{"output": "out.kml"}, true},
+		{"sqlite without output", map[string]string{"format": "sqlite"}, false},
+		{"gpkg with output", map[string]string{"output": "out.gpkg"}, true},
+		{"geojson geometry", map[string]string{"format": "geojson", "geometry": "viewport"}, true},
+		{"unknown geometry", map[string]string{"format": "geojson", "geometry": "hull"}, false},
+		{"known fields", map[string]string{"fields": "id, place_id,default"}, true},
+		{"unknown field", map[string]string{"fields": "id,plus_code"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckOutput(testContext(t, tt.flags))
+			if (err == nil) != tt.ok {
+				t.Fatalf("CheckOutput(%v) = %v, want ok %v", tt.flags, err, tt.ok)
+			}
+		})
+	}
+}
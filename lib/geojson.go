@@ -0,0 +1,169 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"encoding/json"
+	"fmt"
+	"googlemaps.github.io/maps"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"strings"
+)
+
+// Define geojsonOutput Struct
+type geojsonOutput struct {
+	file     *os.File
+	geometry string
+	count    int
+}
+
+// GeoJSON Geometry Struct Field Specification
+type geojsonGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// GeoJSON Feature Struct Field Specification
+type geojsonFeature struct {
+	Type       string           `json:"type"`
+	Geometry   *geojsonGeometry `json:"geometry"`
+	Properties json.RawMessage  `json:"properties"`
+}
+
+// Open GeoJSON Output Writer on Place Geometry Flag
+func newGeoJSONOutput(con *cli.Context, f *os.File) (out *geojsonOutput, e error) {
+	// Validate place geometry
+	switch geometry := strings.ToLower(con.String("geometry")); geometry {
+	case "", "point", "viewport", "bounds":
+		return &geojsonOutput{file: f, geometry: geometry}, nil
+	default:
+		return nil, fmt.Errorf("gmaps: unknown geometry %q", geometry)
+	}
+}
+
+// Build Point Geometry from Record Coordinates
+func pointGeometry(rec interface{}) (geom *geojsonGeometry) {
+	// Skip records without coordinates
+	lat, lng, ok := recordPoint(rec)
+	if ok != true {
+		return nil
+	}
+	return &geojsonGeometry{
+		Type:        "Point",
+		Coordinates: []float64{lng, lat},
+	}
+}
+
+// Build Rectangular Polygon Geometry from Bounds
+func boundsGeometry(b maps.LatLngBounds) (geom *geojsonGeometry) {
+	// Skip empty bounds
+	if b.NorthEast == (maps.LatLng{}) && b.SouthWest == (maps.LatLng{}) {
+		return nil
+	}
+	ne, sw := b.NorthEast, b.SouthWest
+	return &geojsonGeometry{
+		Type: "Polygon",
+		Coordinates: [][][]float64{{
+			{sw.Lng, sw.Lat},
+			{ne.Lng, sw.Lat},
+			{ne.Lng, ne.Lat},
+			{sw.Lng, ne.Lat},
+			{sw.Lng, sw.Lat},
+		}},
+	}
+}
+
+// Build Feature Geometry for a Result Record
+func (gj *geojsonOutput) recordGeometry(rec interface{}) (geom *geojsonGeometry) {
+	// Prefer place polygon and fall back to point
+	if r, ok := rec.(*PlaceRecord); ok {
+		var poly *geojsonGeometry = nil
+		switch gj.geometry {
+		case "viewport":
+			poly = boundsGeometry(r.Viewport)
+		case "bounds":
+			poly = boundsGeometry(r.Bounds)
+		}
+		if poly != nil {
+			return poly
+		}
+	}
+	// Decode route polylines as line strings
+	if r, ok := rec.(*DirectionsRecord); ok && len(r.Polyline) != 0 {
+		path, err := maps.DecodePolyline(r.Polyline)
+		if err == nil {
+			coords := make([][]float64, len(path))
+			for i, p := range path {
+				coords[i] = []float64{p.Lng, p.Lat}
+			}
+			return &geojsonGeometry{Type: "LineString", Coordinates: coords}
+		}
+	}
+	return pointGeometry(rec)
+}
+
+// Define Write Method for geojsonOutput Struct
+func (gj *geojsonOutput) Write(rec interface{}, row []string, extra Passthrough) (e error) {
+	// Encode record properties
+	props, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	// Encode feature
+	b, err := json.Marshal(&geojsonFeature{
+		Type:       "Feature",
+		Geometry:   gj.recordGeometry(rec),
+		Properties: props,
+	})
+	if err != nil {
+		return err
+	}
+	// Open feature collection ahead of first feature
+	if gj.count == 0 {
+		b = append([]byte("{\"type\":\"FeatureCollection\",\"features\":[\n"), b...)
+	} else {
+		b = append([]byte(",\n"), b...)
+	}
+	gj.count++
+	_, err = gj.file.Write(b)
+	return err
+}
+
+// Define Close Method for geojsonOutput Struct
+func (gj *geojsonOutput) Close() (e error) {
+	var err error = nil
+	// Terminate feature collection
+	if gj.count == 0 {
+		_, err = gj.file.WriteString("{\"type\":\"FeatureCollection\",\"features\":[]}\n")
+	} else {
+		_, err = gj.file.WriteString("\n]}\n")
+	}
+	// Close output file
+	if gj.file != os.Stdout {
+		if cerr := gj.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"encoding/xml"
+	"os"
+)
+
+// GPX Document Opening Tags
+const gpxHeader = xml.Header + `<gpx version="1.1" creator="gmaps" xmlns="http://www.topografix.com/GPX/1/1">` + "\n"
+
+// Define gpxOutput Struct
+type gpxOutput struct {
+	file  *os.File
+	count int
+}
+
+// GPX Waypoint Struct Field Specification
+type gpxWaypoint struct {
+	XMLName     xml.Name `xml:"wpt"`
+	Lat         float64  `xml:"lat,attr"`
+	Lng         float64  `xml:"lon,attr"`
+	Elevation   *float64 `xml:"ele,omitempty"`
+	Name        string   `xml:"name"`
+	Description string   `xml:"desc,omitempty"`
+}
+
+// Define Write Method for gpxOutput Struct
+func (gx *gpxOutput) Write(rec interface{}, row []string, extra Passthrough) (e error) {
+	// Skip records without coordinates
+	lat, lng, ok := recordPoint(rec)
+	if ok != true {
+		return nil
+	}
+	// Allocate waypoint
+	name, desc := recordLabel(rec)
+	wpt := &gpxWaypoint{Lat: lat, Lng: lng, Name: name, Description: desc}
+	// Map elevation results to waypoint elevation
+	if r, ok := rec.(*ElevationRecord); ok && r.Status == StatusOK {
+		elevation := r.Elevation
+		wpt.Elevation = &elevation
+	}
+	// Encode waypoint
+	b, err := xml.MarshalIndent(wpt, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	// Open document ahead of first waypoint
+	if gx.count == 0 {
+		b = append([]byte(gpxHeader), b...)
+	}
+	gx.count++
+	_, err = gx.file.Write(append(b, '\n'))
+	return err
+}
+
+// Define Close Method for gpxOutput Struct
+func (gx *gpxOutput) Close() (e error) {
+	var err error = nil
+	// Terminate document
+	if gx.count == 0 {
+		_, err = gx.file.WriteString(gpxHeader)
+	}
+	if err == nil {
+		_, err = gx.file.WriteString("</gpx>\n")
+	}
+	// Close output file
+	if gx.file != os.Stdout {
+		if cerr := gx.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -25,9 +25,13 @@ package gmaps
 import (
 	"bufio"
 	"encoding/csv"
+	"fmt"
+	"googlemaps.github.io/maps"
 	"gopkg.in/urfave/cli.v1"
+	"io"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Define Input Interface
@@ -46,11 +50,11 @@ type consoleInput struct {
 }
 
 // Define Read Method for fileInput Struct
-func (fp *fileInput) Read() (*os.File, *csv.Reader) {
+func (fp *fileInput) Read() (*os.File, *csv.Reader, error) {
 	// Open input file
 	f, err := os.Open(fp.path)
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 	// Allocate new file reader
 	r := csv.NewReader(bufio.NewReader(f))
@@ -58,236 +62,807 @@ func (fp *fileInput) Read() (*os.File, *csv.Reader) {
 	r.Comma = ','
 	r.FieldsPerRecord = -1
 	// Return reader
-	return f, r
+	return f, r, nil
 }
 
 // Define Read Method for consoleInput Struct
 func (cs *consoleInput) Read() *csv.Reader {
 	// Read from stdin
-	r := csv.NewReader(cs.stdin)
+	r := csv.NewReader(bufio.NewReader(cs.stdin))
+	// Parameterize reader
+	r.Comma = ','
+	r.FieldsPerRecord = -1
 	// Return reader
 	return r
 }
 
-// Reader for Processing Elevation Inputs
-func ElevationReadInput(con *cli.Context) (output chan *ElevationRecord, e error) {
+// Structured Address Part Column Flags
+var addressPartFlags = []string{
+	"street-col",
+	"city-col",
+	"state-col",
+	"postal-code-col",
+	"country-col",
+}
+
+// Join Structured Address Parts into a Free Text Address
+func JoinAddress(rec *GeocodeRecord) (address string) {
+	// Collect non-empty parts
+	var parts []string
+	for _, part := range []string{
+		rec.Street,
+		rec.City,
+		strings.TrimSpace(rec.State + " " + rec.PostalCode),
+		rec.Country,
+	} {
+		if part = strings.TrimSpace(part); len(part) != 0 {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Place Search Filter Flags with Optional Per-Row Input Columns
+var placeFilterFlags = []string{
+	"keyword",
+	"name",
+	"type",
+	"language",
+	"min-price",
+	"max-price",
+	"open-now",
+	"rank-by",
+}
+
+// Parse Place Search Filters from Input Columns Falling Back to Flags
+func PlaceFilterInput(con *cli.Context, cols *InputColumns, record []string) (filter PlaceFilter, e error) {
+	// Prefer non-empty input column values
+	value := func(flag string) string {
+		if v := strings.TrimSpace(cols.Get(record, flag+"-col")); len(v) != 0 {
+			return v
+		}
+		return con.String(flag)
+	}
+	filter = PlaceFilter{
+		Keyword:  value("keyword"),
+		Name:     value("name"),
+		Type:     strings.ToLower(value("type")),
+		Language: value("language"),
+		MinPrice: value("min-price"),
+		MaxPrice: value("max-price"),
+		OpenNow:  con.Bool("open-now"),
+		RankBy:   strings.ToLower(value("rank-by")),
+	}
+	// Parse open now column
+	if v := strings.TrimSpace(cols.Get(record, "open-now-col")); len(v) != 0 {
+		openNow, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid open now %q", v)
+		}
+		filter.OpenNow = openNow
+	}
+	return filter, CheckPlaceFilter(filter)
+}
+
+// Parse Location Bias from Input Columns Falling Back to Flags
+func LocationBiasInput(con *cli.Context, cols *InputColumns, record []string) (lat float64, lng float64, radius uint, e error) {
+	// Parse default location bias
+	if len(con.String("location")) != 0 {
+		location, err := maps.ParseLatLng(con.String("location"))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		lat, lng = location.Lat, location.Lng
+	}
+	radius = uint(con.Int("radius"))
+	// Parse location bias from input columns
+	if len(cols.Get(record, "lat-col")) != 0 || len(cols.Get(record, "lng-col")) != 0 {
+		latFloat, err := strconv.ParseFloat(cols.Get(record, "lat-col"), 64)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		lngFloat, err := strconv.ParseFloat(cols.Get(record, "lng-col"), 64)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		lat, lng = latFloat, lngFloat
+	}
+	// Parse radius from input column
+	if len(cols.Get(record, "radius-col")) != 0 {
+		radiusInt, err := strconv.Atoi(cols.Get(record, "radius-col"))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if radiusInt < 0 {
+			return 0, 0, 0, fmt.Errorf("invalid radius %d", radiusInt)
+		}
+		radius = uint(radiusInt)
+	}
+	return lat, lng, radius, nil
+}
+
+// Open Input Reader on File or Console Stdin
+func openInput(con *cli.Context) (file *os.File, reader *csv.Reader, e error) {
 	// Allocate empty reader and file receivers
 	var r *csv.Reader = nil
-    var f *os.File = nil
+	var f *os.File = nil
+	var err error = nil
 	// Switch on context input
 	switch con.IsSet("input") {
 	case true:
 		fp := &fileInput{con.String("input")}
-        f, r = fp.Read()
-        defer f.Close()
+		f, r, err = fp.Read()
 	default:
 		cs := &consoleInput{os.Stdin}
-        r = cs.Read()
+		r = cs.Read()
+	}
+	return f, r, err
+}
+
+// Stream Parsed Input Rows to a Callback Until the Input Is Exhausted
+func streamInput(f *os.File, r *csv.Reader, cols *InputColumns, rej *Rejects, source string, send func(record []string) error) {
+	// Defer file closure
+	if f != nil {
+		defer f.Close()
+	}
+	// Name the source file on rejects when reading several inputs
+	reject := func(row int, record []string, err error) {
+		if len(source) != 0 {
+			err = fmt.Errorf("%s: %v", source, err)
+		}
+		rej.Reject(row, record, err)
+	}
+	// Enter reader loop
+	for {
+		// Read next input row
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		// Reject malformed rows and stop on read failures
+		if perr, ok := err.(*csv.ParseError); ok {
+			reject(perr.StartLine, record, perr.Err)
+			continue
+		}
+		if err != nil {
+			rej.Fail(err)
+			break
+		}
+		// Send row and reject on duplicate ids or parse failures
+		line, _ := r.FieldPos(0)
+		err = cols.Claim(record)
+		if err == nil {
+			err = send(record)
+		}
+		if err != nil {
+			reject(line, record, err)
+		}
 	}
-	// Read in the raw data
-	rawData, err := r.ReadAll()
+}
+
+// Open Input Reader and Resolve Input Column Layout
+func openColumns(con *cli.Context, defaults map[string]int) (file *os.File, reader *csv.Reader, cols *InputColumns, e error) {
+	// Open input reader
+	f, r, err := openInput(con)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	// Resolve input columns
+	cols, err = ReadColumns(con, r, defaults)
+	if err != nil {
+		if f != nil {
+			f.Close()
+		}
+		return nil, nil, nil, err
+	}
+	// Checkpoints key finished records by id so ids must not repeat
+	if con.IsSet("checkpoint") {
+		cols.Unique("id-col")
+	}
+	return f, r, cols, nil
+}
+
+// Reader for Processing Elevation Inputs
+func ElevationReadInput(con *cli.Context, rej *Rejects) (output chan *ElevationRecord, e error) {
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":  0,
+		"lat-col": 1,
+		"lng-col": 2,
+	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	// Allocate empty records channel
-	records := make(chan *ElevationRecord, len(rawData))
-	// Enter reader loops
-	for i, record := range rawData {
-		// Skip header row
-		if i == 0 && con.IsSet("input") {
-			continue
-		} else {
+	records := make(chan *ElevationRecord, channelBuffer)
+	// Enter reader loop
+	go func() {
+		defer close(records)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
 			// Parse lat float
-			latFloat, err := strconv.ParseFloat(record[1], 64)
+			latFloat, err := strconv.ParseFloat(cols.Get(record, "lat-col"), 64)
 			if err != nil {
-				panic(err)
+				return err
 			}
 			// Parse lon float
-			lngFloat, err := strconv.ParseFloat(record[2], 64)
+			lngFloat, err := strconv.ParseFloat(cols.Get(record, "lng-col"), 64)
 			if err != nil {
-				panic(err)
+				return err
 			}
 			// Send formatted record to channel
 			records <- &ElevationRecord{
-				Id:  record[0],
-				Lat: latFloat,
-				Lng: lngFloat,
+				Id:    cols.Get(record, "id-col"),
+				Lat:   latFloat,
+				Lng:   lngFloat,
+				Extra: cols.Extra(con, record),
 			}
-		}
+			return nil
+		})
+	}()
+	return records, err
+}
+
+// Reader for Processing Time Zone Inputs
+func TimezoneReadInput(con *cli.Context, rej *Rejects) (output chan *TimezoneRecord, e error) {
+	// Check default timestamp flag
+	if _, err := ParseTimestamp(con.String("timestamp")); err != nil {
+		return nil, fmt.Errorf("gmaps: %v", err)
 	}
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":        0,
+		"lat-col":       1,
+		"lng-col":       2,
+		"timestamp-col": -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Allocate empty records channel
+	records := make(chan *TimezoneRecord, channelBuffer)
+	// Enter reader loop
+	go func() {
+		defer close(records)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
+			// Parse lat float
+			latFloat, err := strconv.ParseFloat(cols.Get(record, "lat-col"), 64)
+			if err != nil {
+				return err
+			}
+			// Parse lon float
+			lngFloat, err := strconv.ParseFloat(cols.Get(record, "lng-col"), 64)
+			if err != nil {
+				return err
+			}
+			// Parse timestamp falling back to flag
+			value := cols.Get(record, "timestamp-col")
+			if len(strings.TrimSpace(value)) == 0 {
+				value = con.String("timestamp")
+			}
+			timestamp, err := ParseTimestamp(value)
+			if err != nil {
+				return err
+			}
+			// Send formatted record to channel
+			records <- &TimezoneRecord{
+				Id:        cols.Get(record, "id-col"),
+				Lat:       latFloat,
+				Lng:       lngFloat,
+				Timestamp: timestamp,
+				Extra:     cols.Extra(con, record),
+			}
+			return nil
+		})
+	}()
 	return records, err
 }
 
-// Reader for Processing Geocoding Inputs
-func GeocodeReadInput(con *cli.Context) (output chan *GeocodeRecord, e error) {
-	// Allocate empty reader
-	var r *csv.Reader = nil
-	var f *os.File = nil
-    // Switch on context input
-	switch con.IsSet("input") {
-	case true:
-		fp := &fileInput{con.String("input")}
-        f, r = fp.Read()
-        defer f.Close()
-	default:
-		cs := &consoleInput{os.Stdin}
-		r = cs.Read()
+// Reader for Streaming Contiguous Roads Input Points as Traces
+func RoadsReadInput(con *cli.Context, rej *Rejects) (output chan *RoadTrace, e error) {
+	// Validate roads flags
+	err := CheckRoadsFlags(con)
+	if err != nil {
+		return nil, err
 	}
-	// Read input records
-	rawData, err := r.ReadAll()
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":    0,
+		"trace-col": 1,
+		"lat-col":   2,
+		"lng-col":   3,
+	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	// Checkpoints key traces by the contiguous trace id instead of point id
+	cols.Unique("")
 	// Allocate empty records channel
-	records := make(chan *GeocodeRecord, len(rawData))
-	// Enter record channel population loop
-	for i, record := range rawData {
-		// Skip header row
-		if i == 0 && con.IsSet("input") == true {
-			continue
-		} else {
-			records <- &GeocodeRecord{
-				Id:      record[0],
-				Address: record[1]}
+	records := make(chan *RoadTrace, channelBuffer)
+	// Enter reader loop
+	go func() {
+		defer close(records)
+		// Buffer rows of the current trace only
+		var trace *RoadTrace
+		emitted := make(map[string]bool)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
+			// Parse lat float
+			latFloat, err := strconv.ParseFloat(cols.Get(record, "lat-col"), 64)
+			if err != nil {
+				return err
+			}
+			// Parse lon float
+			lngFloat, err := strconv.ParseFloat(cols.Get(record, "lng-col"), 64)
+			if err != nil {
+				return err
+			}
+			// Send finished trace when the trace id changes
+			id := cols.Get(record, "trace-col")
+			if trace == nil || trace.Id != id {
+				if emitted[id] {
+					return fmt.Errorf("trace %q is not contiguous", id)
+				}
+				if trace != nil {
+					records <- trace
+				}
+				trace = &RoadTrace{Id: id}
+				emitted[id] = true
+			}
+			trace.Points = append(trace.Points, &RoadPoint{
+				Id:    cols.Get(record, "id-col"),
+				Lat:   latFloat,
+				Lng:   lngFloat,
+				Extra: cols.Extra(con, record),
+			})
+			return nil
+		})
+		// Send final trace to channel
+		if trace != nil {
+			records <- trace
+		}
+	}()
+	return records, err
+}
+
+// Reader for Processing Geocoding Inputs
+func GeocodeReadInput(con *cli.Context, rej *Rejects) (output chan *GeocodeRecord, e error) {
+	// Map free text address unless only structured parts are given
+	address := 1
+	for _, flag := range addressPartFlags {
+		if con.IsSet(flag) && con.IsSet("address-col") != true {
+			address = -1
 		}
 	}
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":          0,
+		"address-col":     address,
+		"street-col":      -1,
+		"city-col":        -1,
+		"state-col":       -1,
+		"postal-code-col": -1,
+		"country-col":     -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Allocate empty records channel
+	records := make(chan *GeocodeRecord, channelBuffer)
+	// Enter record channel population loop
+	go func() {
+		defer close(records)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
+			rec := &GeocodeRecord{
+				Id:         cols.Get(record, "id-col"),
+				Address:    cols.Get(record, "address-col"),
+				Street:     cols.Get(record, "street-col"),
+				City:       cols.Get(record, "city-col"),
+				State:      cols.Get(record, "state-col"),
+				PostalCode: cols.Get(record, "postal-code-col"),
+				Country:    cols.Get(record, "country-col"),
+				Extra:      cols.Extra(con, record)}
+			// Build free text address from structured parts
+			if len(rec.Address) == 0 {
+				rec.Address = JoinAddress(rec)
+			}
+			records <- rec
+			return nil
+		})
+	}()
 	return records, err
 }
 
 // Reader for Processing Reverse Geocoding Inputs
-func ReverseGeocodeReadInput(con *cli.Context) (output chan *GeocodeRecord, e error) {
-	// Allocate empty reader
-	var r *csv.Reader = nil
-    var f *os.File = nil
-	// Switch on context input
-	switch con.IsSet("input") {
-	case true:
-		fp := &fileInput{con.String("input")}
-        f, r = fp.Read()
-        defer f.Close()
-	default:
-		cs := &consoleInput{os.Stdin}
-		r = cs.Read()
-	}
-	// Read input records
-	rawData, err := r.ReadAll()
+func ReverseGeocodeReadInput(con *cli.Context, rej *Rejects) (output chan *GeocodeRecord, e error) {
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":  0,
+		"lat-col": 1,
+		"lng-col": 2,
+	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	// Allocate empty records channel
-	records := make(chan *GeocodeRecord, len(rawData))
+	records := make(chan *GeocodeRecord, channelBuffer)
 	// Enter record channel population loop
-	for i, record := range rawData {
-		// Skip header row
-		if i == 0 && con.IsSet("input") {
-			continue
-		} else {
+	go func() {
+		defer close(records)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
 			// Parse lat float
-			latFloat, err := strconv.ParseFloat(record[1], 64)
+			latFloat, err := strconv.ParseFloat(cols.Get(record, "lat-col"), 64)
 			if err != nil {
-				panic(err)
+				return err
 			}
 			// Parse lng float
-			lngFloat, err := strconv.ParseFloat(record[2], 64)
+			lngFloat, err := strconv.ParseFloat(cols.Get(record, "lng-col"), 64)
 			if err != nil {
-				panic(err)
+				return err
 			}
 			// Write to record
 			records <- &GeocodeRecord{
-				Id:  record[0],
-				Lat: latFloat,
-				Lng: lngFloat}
-		}
-	}
+				Id:    cols.Get(record, "id-col"),
+				Lat:   latFloat,
+				Lng:   lngFloat,
+				Extra: cols.Extra(con, record)}
+			return nil
+		})
+	}()
 	return records, err
 }
 
 // Reader for Processing Place Nearby Inputs
-func PlaceNearbyReadInput(con *cli.Context) (output chan *PlaceRecord, e error) {
-	// Allocate empty reader
-	var r *csv.Reader = nil
-    var f *os.File = nil
-	// Switch on context input
-	switch con.IsSet("input") {
-	case true:
-		fp := &fileInput{con.String("input")}
-        f, r = fp.Read()
-        defer f.Close()
-	default:
-		cs := &consoleInput{os.Stdin}
-		r = cs.Read()
+func PlaceNearbyReadInput(con *cli.Context, rej *Rejects) (output chan *PlaceRecord, e error) {
+	// Validate search filter flags
+	err := CheckPlaceFlags(con)
+	if err != nil {
+		return nil, err
 	}
-	// Read input records
-	rawData, err := r.ReadAll()
+	// Open input reader
+	defaults := map[string]int{
+		"id-col":     0,
+		"lat-col":    1,
+		"lng-col":    2,
+		"radius-col": 3,
+	}
+	for _, flag := range placeFilterFlags {
+		defaults[flag+"-col"] = -1
+	}
+	f, r, cols, err := openColumns(con, defaults)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	// Allocate empty records channel
-	records := make(chan *PlaceRecord, len(rawData))
+	records := make(chan *PlaceRecord, channelBuffer)
 	// Enter record channel population loop
-	for i, record := range rawData {
-		// Skip header row
-		if i == 0 && con.IsSet("input") {
-			continue
-		} else {
+	go func() {
+		defer close(records)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
 			// Parse lat float
-			latFloat, err := strconv.ParseFloat(record[1], 64)
+			latFloat, err := strconv.ParseFloat(cols.Get(record, "lat-col"), 64)
 			if err != nil {
-				panic(err)
+				return err
 			}
 			// Parse lon float
-			lngFloat, err := strconv.ParseFloat(record[2], 64)
+			lngFloat, err := strconv.ParseFloat(cols.Get(record, "lng-col"), 64)
 			if err != nil {
-				panic(err)
+				return err
 			}
-			// Parse radius to int
-			radiusInt, err := strconv.Atoi(record[3])
+			// Parse search filters
+			filter, err := PlaceFilterInput(con, cols, record)
 			if err != nil {
-				panic(err)
+				return err
+			}
+			// Parse radius to int unless ranking by distance
+			radiusInt := 0
+			if filter.RankBy != "distance" || len(cols.Get(record, "radius-col")) != 0 {
+				radiusInt, err = strconv.Atoi(cols.Get(record, "radius-col"))
+				if err != nil {
+					return err
+				}
+			}
+			if radiusInt < 0 {
+				return fmt.Errorf("invalid radius %d", radiusInt)
 			}
 			records <- &PlaceRecord{
-				Id:     record[0],
+				Id:     cols.Get(record, "id-col"),
 				Lat:    latFloat,
 				Lng:    lngFloat,
-				Radius: uint(radiusInt)}
-		}
-	}
+				Radius: uint(radiusInt),
+				Filter: filter,
+				Extra:  cols.Extra(con, record)}
+			return nil
+		})
+	}()
 	return records, err
 }
 
 // Reader for Processing Place Detail Inputs
-func PlaceDetailsReadInput(con *cli.Context) (output chan *PlaceRecord, e error) {
-	// Allocate empty reader
-	var r *csv.Reader = nil
-    var f *os.File = nil
-	// Switch on context input
-	switch con.IsSet("input") {
-	case true:
-		fp := &fileInput{con.String("input")}
-        f, r = fp.Read()
-        defer f.Close()
+func PlaceDetailsReadInput(con *cli.Context, rej *Rejects) (output chan *PlaceRecord, e error) {
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":       0,
+		"place-id-col": 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Allocate empty records channel
+	records := make(chan *PlaceRecord, channelBuffer)
+	// Enter record channel population loop
+	go func() {
+		defer close(records)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
+			records <- &PlaceRecord{
+				Id:      cols.Get(record, "id-col"),
+				PlaceId: cols.Get(record, "place-id-col"),
+				Extra:   cols.Extra(con, record)}
+			return nil
+		})
+	}()
+	return records, err
+}
+
+// Reader for Processing Place Search Inputs
+func PlaceSearchReadInput(con *cli.Context, rej *Rejects) (output chan *PlaceRecord, e error) {
+	return placeQueryReadInput(con, rej, nil)
+}
+
+// Reader for Processing Find Place Inputs
+func PlaceFindReadInput(con *cli.Context, rej *Rejects) (output chan *PlaceRecord, e error) {
+	// Switch on input type
+	switch inputType := strings.ToLower(con.String("input-type")); inputType {
+	case "textquery":
+		return placeQueryReadInput(con, rej, nil)
+	case "phonenumber":
+		return placeQueryReadInput(con, rej, NormalizePhone)
 	default:
-		cs := &consoleInput{os.Stdin}
-		r = cs.Read()
+		return nil, fmt.Errorf("gmaps: unknown input type %q", inputType)
+	}
+}
+
+// Normalize a Phone Number to International Format Digits
+func NormalizePhone(phone string) (out string) {
+	// Keep leading plus sign and digits
+	var b strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		if (r == '+' && i == 0) || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Reader for Processing Free Text Place Query Inputs with Location Bias
+func placeQueryReadInput(con *cli.Context, rej *Rejects, normalize func(string) string) (output chan *PlaceRecord, e error) {
+	// Validate search filters
+	err := CheckPlaceFlags(con)
+	if err != nil {
+		return nil, err
 	}
-	// Read input records
-	rawData, err := r.ReadAll()
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":     0,
+		"query-col":  1,
+		"lat-col":    -1,
+		"lng-col":    -1,
+		"radius-col": -1,
+	})
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	// Allocate empty records channel
-	records := make(chan *PlaceRecord, len(rawData))
+	records := make(chan *PlaceRecord, channelBuffer)
 	// Enter record channel population loop
-	for i, record := range rawData {
-		// Skip header row
-		if i == 0 && con.IsSet("input") {
-			continue
-		} else {
-			records <- &PlaceRecord{
-				Id:      record[0],
-				PlaceId: record[1]}
+	go func() {
+		defer close(records)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
+			// Parse location bias
+			lat, lng, radius, err := LocationBiasInput(con, cols, record)
+			if err != nil {
+				return err
+			}
+			rec := &PlaceRecord{
+				Id:     cols.Get(record, "id-col"),
+				Query:  strings.TrimSpace(cols.Get(record, "query-col")),
+				Lat:    lat,
+				Lng:    lng,
+				Radius: radius,
+				Extra:  cols.Extra(con, record)}
+			// Normalize query text
+			if normalize != nil {
+				rec.Query = normalize(rec.Query)
+			}
+			records <- rec
+			return nil
+		})
+	}()
+	return records, err
+}
+
+// Reader for Processing Place Autocomplete Inputs
+func AutocompleteReadInput(con *cli.Context, rej *Rejects) (output chan *AutocompleteRecord, e error) {
+	// Validate autocomplete flags
+	err := CheckAutocompleteFlags(con)
+	if err != nil {
+		return nil, err
+	}
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":      0,
+		"input-col":   1,
+		"session-col": -1,
+		"lat-col":     -1,
+		"lng-col":     -1,
+		"radius-col":  -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Allocate empty records channel
+	records := make(chan *AutocompleteRecord, channelBuffer)
+	// Enter record channel population loop
+	go func() {
+		defer close(records)
+		tokens := make(map[string]maps.PlaceAutocompleteSessionToken)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
+			// Parse location bias
+			lat, lng, radius, err := LocationBiasInput(con, cols, record)
+			if err != nil {
+				return err
+			}
+			rec := &AutocompleteRecord{
+				Id:      cols.Get(record, "id-col"),
+				Input:   cols.Get(record, "input-col"),
+				Session: cols.Get(record, "session-col"),
+				Lat:     lat,
+				Lng:     lng,
+				Radius:  radius,
+				Extra:   cols.Extra(con, record)}
+			// Share session tokens across rows of the same session
+			token, ok := tokens[rec.Session]
+			if ok != true || len(rec.Session) == 0 {
+				token = maps.NewPlaceAutocompleteSessionToken()
+				tokens[rec.Session] = token
+			}
+			rec.Token = token
+			records <- rec
+			return nil
+		})
+	}()
+	return records, err
+}
+
+// Split a Pipe Separated Input List
+func splitList(value string) (items []string) {
+	for _, item := range strings.Split(value, "|") {
+		if item = strings.TrimSpace(item); len(item) != 0 {
+			items = append(items, item)
 		}
 	}
+	return items
+}
+
+// Reader for Processing Directions Inputs
+func DirectionsReadInput(con *cli.Context, rej *Rejects) (output chan *DirectionsRecord, e error) {
+	// Validate travel option flags
+	err := CheckTravelFlags(con)
+	if err != nil {
+		return nil, err
+	}
+	// Open input reader
+	f, r, cols, err := openColumns(con, map[string]int{
+		"id-col":             0,
+		"origin-col":         1,
+		"destination-col":    2,
+		"waypoints-col":      -1,
+		"mode-col":           -1,
+		"departure-time-col": -1,
+		"avoid-col":          -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Allocate empty records channel
+	records := make(chan *DirectionsRecord, channelBuffer)
+	// Enter record channel population loop
+	go func() {
+		defer close(records)
+		streamInput(f, r, cols, rej, "", func(record []string) error {
+			// Prefer non-empty input column values
+			value := func(flag string) string {
+				if v := strings.TrimSpace(cols.Get(record, flag+"-col")); len(v) != 0 {
+					return v
+				}
+				return con.String(flag)
+			}
+			rec := &DirectionsRecord{
+				Id:          cols.Get(record, "id-col"),
+				Origin:      strings.TrimSpace(cols.Get(record, "origin-col")),
+				Destination: strings.TrimSpace(cols.Get(record, "destination-col")),
+				Waypoints:   splitList(cols.Get(record, "waypoints-col")),
+				Mode:        strings.ToLower(value("mode")),
+				Avoid:       splitList(strings.ToLower(value("avoid"))),
+				Extra:       cols.Extra(con, record)}
+			// Parse departure time
+			departure, err := ParseDepartureTime(value("departure-time"))
+			if err != nil {
+				return err
+			}
+			rec.DepartureTime = departure
+			// Validate travel options
+			err = CheckTravelOptions(rec.Mode, rec.Avoid)
+			if err != nil {
+				return err
+			}
+			records <- rec
+			return nil
+		})
+	}()
 	return records, err
 }
 
+// Reader for Processing Distance Matrix Origin and Destination Files
+func MatrixReadInput(con *cli.Context, rej *Rejects) (origins []*MatrixPoint, destinations []*MatrixPoint, e error) {
+	// Validate travel option flags
+	err := CheckTravelFlags(con)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Read origin locations
+	origins, err = readMatrixPoints(con, rej, con.String("origins"))
+	if err != nil {
+		return nil, nil, err
+	}
+	// Read destination locations
+	destinations, err = readMatrixPoints(con, rej, con.String("destinations"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return origins, destinations, nil
+}
+
+// Read Distance Matrix Locations from an Input File
+func readMatrixPoints(con *cli.Context, rej *Rejects, path string) (points []*MatrixPoint, e error) {
+	// Open input reader
+	fp := &fileInput{path}
+	f, r, err := fp.Read()
+	if err != nil {
+		return nil, err
+	}
+	// Resolve input columns with a header row unless disabled
+	cols, err := readColumns(con, r, map[string]int{
+		"id-col":       0,
+		"location-col": 1,
+		"lat-col":      -1,
+		"lng-col":      -1,
+	}, con.Bool("no-header") != true)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	// Collect locations
+	streamInput(f, r, cols, rej, path, func(record []string) error {
+		location := strings.TrimSpace(cols.Get(record, "location-col"))
+		// Prefer coordinate columns when mapped
+		if len(cols.Get(record, "lat-col")) != 0 || len(cols.Get(record, "lng-col")) != 0 {
+			latFloat, err := strconv.ParseFloat(cols.Get(record, "lat-col"), 64)
+			if err != nil {
+				return err
+			}
+			lngFloat, err := strconv.ParseFloat(cols.Get(record, "lng-col"), 64)
+			if err != nil {
+				return err
+			}
+			location = strconv.FormatFloat(latFloat, 'f', -1, 64) + "," + strconv.FormatFloat(lngFloat, 'f', -1, 64)
+		}
+		if len(location) == 0 {
+			return fmt.Errorf("location missing")
+		}
+		points = append(points, &MatrixPoint{
+			Id:       cols.Get(record, "id-col"),
+			Location: location,
+		})
+		return nil
+	})
+	return points, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Define jsonOutput Struct
+type jsonOutput struct {
+	file  *os.File
+	lines bool
+	count int
+}
+
+// Define Write Method for jsonOutput Struct
+func (jo *jsonOutput) Write(rec interface{}, row []string, extra Passthrough) (e error) {
+	// Encode record
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	// Switch on line delimited or array framing
+	switch {
+	case jo.lines:
+		b = append(b, '\n')
+	case jo.count == 0:
+		b = append([]byte("[\n"), b...)
+	default:
+		b = append([]byte(",\n"), b...)
+	}
+	jo.count++
+	_, err = jo.file.Write(b)
+	return err
+}
+
+// Define Close Method for jsonOutput Struct
+func (jo *jsonOutput) Close() (e error) {
+	var err error = nil
+	// Terminate array framing
+	if jo.lines != true {
+		if jo.count == 0 {
+			_, err = jo.file.WriteString("[]\n")
+		} else {
+			_, err = jo.file.WriteString("\n]\n")
+		}
+	}
+	// Close output file
+	if jo.file != os.Stdout {
+		if cerr := jo.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"encoding/xml"
+	"os"
+	"strconv"
+)
+
+// KML Document Opening Tags
+const kmlHeader = xml.Header + `<kml xmlns="http://www.opengis.net/kml/2.2">` + "\n  <Document>\n"
+
+// Define kmlOutput Struct
+type kmlOutput struct {
+	file   *os.File
+	header []string
+	count  int
+}
+
+// KML Placemark Struct Field Specification
+type kmlPlacemark struct {
+	XMLName     xml.Name  `xml:"Placemark"`
+	Name        string    `xml:"name"`
+	Description string    `xml:"description,omitempty"`
+	Data        []kmlData `xml:"ExtendedData>Data,omitempty"`
+	Point       *kmlPoint `xml:"Point,omitempty"`
+}
+
+// KML Extended Data Struct Field Specification
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// KML Point Struct Field Specification
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// Define Write Method for kmlOutput Struct
+func (ko *kmlOutput) Write(rec interface{}, row []string, extra Passthrough) (e error) {
+	// Allocate placemark
+	name, desc := recordLabel(rec)
+	pm := &kmlPlacemark{Name: name, Description: desc}
+	// Attach output columns as extended data
+	names := append(append([]string{}, ko.header...), extra.Header...)
+	values := append(append([]string{}, row...), extra.Values...)
+	for i := range values {
+		if i < len(names) {
+			pm.Data = append(pm.Data, kmlData{names[i], values[i]})
+		}
+	}
+	// Attach point geometry
+	if lat, lng, ok := recordPoint(rec); ok {
+		pm.Point = &kmlPoint{
+			strconv.FormatFloat(lng, 'f', -1, 64) + "," + strconv.FormatFloat(lat, 'f', -1, 64),
+		}
+	}
+	// Encode placemark
+	b, err := xml.MarshalIndent(pm, "    ", "  ")
+	if err != nil {
+		return err
+	}
+	// Open document ahead of first placemark
+	if ko.count == 0 {
+		b = append([]byte(kmlHeader), b...)
+	}
+	ko.count++
+	_, err = ko.file.Write(append(b, '\n'))
+	return err
+}
+
+// Define Close Method for kmlOutput Struct
+func (ko *kmlOutput) Close() (e error) {
+	var err error = nil
+	// Terminate document
+	if ko.count == 0 {
+		_, err = ko.file.WriteString(kmlHeader)
+	}
+	if err == nil {
+		_, err = ko.file.WriteString("  </Document>\n</kml>\n")
+	}
+	// Close output file
+	if ko.file != os.Stdout {
+		if cerr := ko.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
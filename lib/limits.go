@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"errors"
+	"fmt"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/net/context"
+	"golang.org/x/time/rate"
+	"gopkg.in/urfave/cli.v1"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+	_ "time/tzdata"
+)
+
+// Error Returned Once the Query Budget for the Day Has Been Spent
+var ErrDailyLimit = errors.New("gmaps: daily query limit reached")
+
+// Usage Database Bucket Holding Query Counts by Pacific Date
+const usageBucket = "usage"
+
+// Time Zone in Which Google Maps Platform Daily Quotas Reset
+const usageZone = "America/Los_Angeles"
+
+// Rate Limiter Struct Field Specification
+type RateLimiter struct {
+	bucket *rate.Limiter
+	usage  *bolt.DB
+	zone   *time.Location
+	limit  int
+	mu     sync.Mutex
+}
+
+// Initialize New Rate Limiter from Context Flags
+func NewRateLimiter(con *cli.Context) (lmt *RateLimiter, e error) {
+	// Allocate limiter
+	lmt = &RateLimiter{
+		limit: con.Int("daily-limit"),
+	}
+	// Build token bucket on optional queries per second flag
+	if qps := con.Int("qps"); qps > 0 {
+		lmt.bucket = rate.NewLimiter(rate.Limit(qps), qps)
+	}
+	// Open persistent usage counts on optional daily limit flag
+	if lmt.limit > 0 {
+		fp, err := UsageFilepath(con.String("usage-file"))
+		if err != nil {
+			return nil, err
+		}
+		lmt.zone, err = time.LoadLocation(usageZone)
+		if err != nil {
+			return nil, err
+		}
+		lmt.usage, err = bolt.Open(fp, 0644, &bolt.Options{Timeout: time.Second})
+		if err != nil {
+			return nil, fmt.Errorf("gmaps: cannot open usage file %s: %v", fp, err)
+		}
+	}
+	return lmt, nil
+}
+
+// Format Usage Filepath Defaulting to the User Home Directory
+func UsageFilepath(fp string) (out string, e error) {
+	// Use explicit filepath when given
+	if len(fp) != 0 {
+		return fp, nil
+	}
+	// Get user info
+	usr, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(usr.HomeDir, "gmaps_usage.db"), nil
+}
+
+// Wait for an Available Token and Spend One Query from the Budget
+func (l *RateLimiter) Wait() (e error) {
+	// Check and spend remaining query budget for the current Pacific day
+	if l.usage != nil {
+		l.mu.Lock()
+		err := l.usage.Update(func(tx *bolt.Tx) error {
+			b, err := tx.CreateBucketIfNotExists([]byte(usageBucket))
+			if err != nil {
+				return err
+			}
+			day := []byte(time.Now().In(l.zone).Format("2006-01-02"))
+			count, _ := strconv.Atoi(string(b.Get(day)))
+			if count >= l.limit {
+				return ErrDailyLimit
+			}
+			return b.Put(day, []byte(strconv.Itoa(count+1)))
+		})
+		l.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	// Block until a token is available
+	if l.bucket != nil {
+		return l.bucket.Wait(context.Background())
+	}
+	return nil
+}
+
+// Close Persistent Usage Counts
+func (l *RateLimiter) Close() (e error) {
+	// Skip when no daily limit is set
+	if l == nil || l.usage == nil {
+		return nil
+	}
+	return l.usage.Close()
+}
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestRateLimiterDailyLimit(t *testing.T) {
+	con := testContext(t, map[string]string{
+		"daily-limit": "2",
+		"usage-file":  filepath.Join(t.TempDir(), "usage.db"),
+	})
+	// Client IP check spends the first query
+	if err := CheckClientIP(con, nil); err != nil {
+		t.Fatalf("CheckClientIP() = %v", err)
+	}
+	lmt, err := NewRateLimiter(con)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lmt.Close()
+	if err := lmt.Wait(); err != nil {
+		t.Fatalf("second Wait() = %v, want nil", err)
+	}
+	if err := lmt.Wait(); err != ErrDailyLimit {
+		t.Fatalf("third Wait() = %v, want %v", err, ErrDailyLimit)
+	}
+	// Usage is counted on the Pacific date
+	day := time.Now().In(lmt.zone).Format("2006-01-02")
+	err = lmt.usage.View(func(tx *bolt.Tx) error {
+		if got := string(tx.Bucket([]byte(usageBucket)).Get([]byte(day))); got != "2" {
+			t.Errorf("usage on %s = %q, want 2", day, got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -25,317 +25,893 @@ package gmaps
 import (
 	"encoding/csv"
 	"fmt"
+	"googlemaps.github.io/maps"
 	"gopkg.in/urfave/cli.v1"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
 
 // Define Output Interface
 type Output interface {
-    Write() *csv.Writer
+	Write(rec interface{}, row []string, extra Passthrough) error
+	Close() error
 }
 
 // Define fileOutput Struct
 type fileOutput struct {
-    path string
+	path string
 }
 
-// Define consoleOutput Struct
-type consoleOutput struct {
-    stdin *os.File
+// Define csvOutput Struct
+type csvOutput struct {
+	file *os.File
+	hw   *headerWriter
+}
+
+// Define Open Method for fileOutput Struct
+func (fp *fileOutput) Open() (file *os.File, e error) {
+	// Format output filepath
+	out, err := OutputFilepath(fp.path)
+	if err != nil {
+		return nil, err
+	}
+	// Open output file
+	return os.Create(out)
 }
 
 // Define Write Method for fileOutput Struct
-func (fp *fileOutput) Write() (file *os.File, writer *csv.Writer) {
-        // Format output filepath
-		out, err := OutputFilepath(fp.path)
-		if err != nil {
-			panic(err)
+func (fp *fileOutput) Write() (file *os.File, writer *csv.Writer, e error) {
+	// Open output file
+	f, err := fp.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Allocate new file writer
+	w := csv.NewWriter(f)
+	// Reader writer
+	return f, w, nil
+}
+
+// Define Write Method for csvOutput Struct
+func (co *csvOutput) Write(rec interface{}, row []string, extra Passthrough) (e error) {
+	return co.hw.Write(row, extra)
+}
+
+// Define Close Method for csvOutput Struct
+func (co *csvOutput) Close() (e error) {
+	err := co.hw.Close()
+	// Close output file
+	if co.file != os.Stdout {
+		if cerr := co.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Output Formats Inferred from Output File Extensions
+var formatExtensions = map[string]string{
+	".csv":     "csv",
+	".json":    "json",
+	".jsonl":   "jsonl",
+	".ndjson":  "jsonl",
+	".geojson": "geojson",
+	".kml":     "kml",
+	".gpx":     "gpx",
+	".sqlite":  "sqlite",
+	".sqlite3": "sqlite",
+	".db":      "sqlite",
+	".gpkg":    "gpkg",
+}
+
+// Resolve Output Format from Format Flag or Output File Extension
+func OutputFormat(con *cli.Context) (format string) {
+	// Prefer explicit format flag
+	if con.IsSet("format") || con.IsSet("output") != true {
+		return strings.ToLower(con.String("format"))
+	}
+	// Infer from output file extension
+	ext := strings.ToLower(filepath.Ext(con.String("output")))
+	if format, ok := formatExtensions[ext]; ok {
+		return format
+	}
+	return strings.ToLower(con.String("format"))
+}
+
+// Check Output Format, Geometry and Fields Flags Before Any Requests
+func CheckOutput(con *cli.Context) (e error) {
+	// Validate output format
+	switch format := OutputFormat(con); format {
+	case "", "csv", "json", "jsonl", "kml", "gpx":
+	case "sqlite", "gpkg":
+		if con.IsSet("output") != true {
+			return fmt.Errorf("gmaps: %s output requires an output file", format)
+		}
+	case "geojson":
+		if _, err := newGeoJSONOutput(con, nil); err != nil {
+			return err
 		}
-		// Open output file
-		f, err := os.Create(out)
+	default:
+		return fmt.Errorf("gmaps: unknown output format %q", format)
+	}
+	// Validate geocode output fields
+	if len(con.String("fields")) != 0 {
+		if _, err := SelectGeocodeFields(con, GeocodeDefaultFields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Open Output Writer on Format and Output File Flags
+func NewOutput(con *cli.Context, header []string) (out Output, e error) {
+	// Open database output sinks
+	format := OutputFormat(con)
+	if format == "sqlite" || format == "gpkg" {
+		return newSQLiteOutput(con, header, format)
+	}
+	// Allocate output file receiver
+	var f *os.File = os.Stdout
+	var err error = nil
+	// Open output file
+	if con.IsSet("output") {
+		fp := &fileOutput{con.String("output")}
+		f, err = fp.Open()
 		if err != nil {
-			panic(err)
+			return nil, err
+		}
+	}
+	// Switch on output format
+	switch format {
+	case "", "csv":
+		return &csvOutput{
+			file: f,
+			hw: &headerWriter{
+				w:       csv.NewWriter(f),
+				header:  header,
+				written: con.Bool("no-output-header"),
+			},
+		}, nil
+	case "json":
+		return &jsonOutput{file: f}, nil
+	case "jsonl":
+		return &jsonOutput{file: f, lines: true}, nil
+	case "geojson":
+		out, err := newGeoJSONOutput(con, f)
+		if err != nil && f != os.Stdout {
+			f.Close()
+		}
+		return out, err
+	case "kml":
+		return &kmlOutput{file: f, header: header}, nil
+	case "gpx":
+		return &gpxOutput{file: f}, nil
+	default:
+		if f != os.Stdout {
+			f.Close()
 		}
-		// Allocate new file writer
-		w := csv.NewWriter(f)
-        // Reader writer
-        return f, w
+		return nil, fmt.Errorf("gmaps: unknown output format %q", format)
+	}
+}
+
+// CSV Writer with Deferred Header Row Struct Field Specification
+type headerWriter struct {
+	w       *csv.Writer
+	header  []string
+	written bool
+}
+
+// Write Header Row Extended with Pass-Through Column Names Once
+func (hw *headerWriter) writeHeader(extra []string) (e error) {
+	// Skip when header has been written
+	if hw.written {
+		return nil
+	}
+	hw.written = true
+	return hw.w.Write(append(append([]string{}, hw.header...), extra...))
+}
+
+// Write Result Row with Pass-Through Column Values and Flush to Output
+func (hw *headerWriter) Write(row []string, extra Passthrough) (e error) {
+	// Write header ahead of first row
+	err := hw.writeHeader(extra.Header)
+	if err != nil {
+		return err
+	}
+	// Write row and flush
+	err = hw.w.Write(append(row, extra.Values...))
+	if err != nil {
+		return err
+	}
+	hw.w.Flush()
+	return hw.w.Error()
 }
 
-// CSV Writer for Generating Output Elevation Results Files
+// Write Header Row for Empty Results and Flush to Output
+func (hw *headerWriter) Close() (e error) {
+	err := hw.writeHeader(nil)
+	hw.w.Flush()
+	if err != nil {
+		return err
+	}
+	return hw.w.Error()
+}
+
+// Insert Candidate Rank After the Id Column When Multiple Results Are Requested
+func rankColumn(con *cli.Context, row []string, rank string) (out []string) {
+	// Skip when a single result is requested
+	if MaxResults(con) < 2 {
+		return row
+	}
+	return append([]string{row[0], rank}, row[1:]...)
+}
+
+// Writer for Generating Output Elevation Results Files
 func ElevationWriteOutput(con *cli.Context, results <-chan *ElevationRecord) (e error) {
-	// Allocate empty error reciever
-	var err error
-	// Switch on output file flag
-	switch con.IsSet("output") {
-	case true:
-        // Generate interface types
-        fp := &fileOutput{con.String("output")}
-        f, w := fp.Write()
-        // Defer Closures
-        defer f.Close()
-        defer w.Flush()
-        // Set writer formatting
-		err = w.Write([]string{
-			"id",
-			"lat",
-			"lng",
-			"elevation",
-			"resolution",
-			"note"})
+	// Open output writer
+	out, err := NewOutput(con, []string{
+		"id",
+		"lat",
+		"lng",
+		"elevation",
+		"resolution",
+		"status",
+		"note"})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
+		lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
+		elevationString := strconv.FormatFloat(record.Elevation, 'f', -1, 64)
+		resolutionString := strconv.FormatFloat(record.Resolution, 'f', -1, 64)
+		// Write to output
+		err = out.Write(record, []string{
+			record.Id,
+			latString,
+			lngString,
+			elevationString,
+			resolutionString,
+			record.Status,
+			record.Note}, record.Extra)
 		if err != nil {
-			panic(err)
-		}
-		// Enter Writer Loop
-		lim := len(results)
-		for i := 0; i < lim; i++ {
-			// Extract current record from channel
-			record := <-results
-            //DEBUG 
-            fmt.Println(record.Id)
-			// Format strings
-			latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
-			lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
-			elevationString := strconv.FormatFloat(record.Elevation, 'f', -1, 64)
-			resolutionString := strconv.FormatFloat(record.Resolution, 'f', -1, 64)
-			// Write to file
-			err = w.Write([]string{
-				record.Id,
-				latString,
-				lngString,
-				elevationString,
-				resolutionString,
-				record.Note})
-			if err != nil {
-				panic(err)
-			}
+			return err
+		}
+	}
+	return err
+}
+
+// Writer for Generating Output Time Zone Results Files
+func TimezoneWriteOutput(con *cli.Context, results <-chan *TimezoneRecord) (e error) {
+	// Open output writer
+	out, err := NewOutput(con, []string{
+		"id",
+		"lat",
+		"lng",
+		"timestamp",
+		"time_zone_id",
+		"time_zone_name",
+		"raw_offset",
+		"dst_offset",
+		"status",
+		"note"})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
 		}
-    default:
-		// Enter writer loop
-		lim := len(results)
-		for i := 0; i < lim; i++ {
-			// Extract current record from channel
-			record := <-results
-			// Format strings
-			latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
-			lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
-			elevationString := strconv.FormatFloat(record.Elevation, 'f', -1, 64)
-			resolutionString := strconv.FormatFloat(record.Resolution, 'f', -1, 64)
-			// Print to stdout
-			values := []string{
-				record.Id,
-				latString,
-				lngString,
-				elevationString,
-				resolutionString,
-				record.Note}
-			fmt.Println(strings.Join(values, ","))
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
+		lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
+		timestampString := strconv.FormatInt(record.Timestamp, 10)
+		rawString := strconv.Itoa(record.RawOffset)
+		dstString := strconv.Itoa(record.DstOffset)
+		// Write to output
+		err = out.Write(record, []string{
+			record.Id,
+			latString,
+			lngString,
+			timestampString,
+			record.TimeZoneId,
+			record.TimeZoneName,
+			rawString,
+			dstString,
+			record.Status,
+			record.Note}, record.Extra)
+		if err != nil {
+			return err
 		}
 	}
 	return err
 }
 
-// CSV Writer for Generating Geocoding Output Results Files
+// Writer for Generating Geocoding Output Results Files
 func GeocodeWriteOutput(con *cli.Context, results <-chan *GeocodeRecord) (e error) {
-	// Allocated empty error reciever
-	var err error = nil
-	// Switch on output file flag
-	switch con.IsSet("output") {
-	case true:
-        // Generate interface types
-        fp := &fileOutput{con.String("output")}
-        f, w := fp.Write()
-		// Defer closures
-		defer f.Close()
-        defer w.Flush()
-		// Format record outputs
-		err = w.Write([]string{
-			"id",
-			"address",
-			"lat",
-			"lng",
-			"note"})
+	return writeGeocodeOutput(con, results, GeocodeDefaultFields)
+}
+
+// Writer for Generating Reverse Geocoding Output Results Files
+func ReverseGeocodeWriteOutput(con *cli.Context, results <-chan *GeocodeRecord) (e error) {
+	return writeGeocodeOutput(con, results, ReverseGeocodeDefaultFields)
+}
+
+// Writer for Geocode Records with Selectable Output Fields
+func writeGeocodeOutput(con *cli.Context, results <-chan *GeocodeRecord, defaults []string) (e error) {
+	// Select output fields
+	fields, err := SelectGeocodeFields(con, defaults)
+	if err != nil {
+		return err
+	}
+	// Open output writer
+	out, err := NewOutput(con, GeocodeHeader(fields))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Write to output
+		err = out.Write(record, GeocodeRow(fields, record), record.Extra)
 		if err != nil {
-			panic(err)
-		}
-		// Enter writer loop
-		lim := len(results)
-		for i := 0; i < lim; i++ {
-			// Extract current record from channel
-			record := <-results
-			// Format strings
-			latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
-			lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
-			// Write to output file
-			err := w.Write([]string{
-				record.Id,
-				record.Address,
-				latString,
-				lngString,
-				record.Note})
-			if err != nil {
-				panic(err)
-			}
+			return err
+		}
+	}
+	return err
+}
+
+// Writer for Generating Places Nearby Output Results Files
+func PlaceNearbyWriteOutput(con *cli.Context, results <-chan *PlaceRecord) (e error) {
+	// Open output writer
+	out, err := NewOutput(con, rankColumn(con, []string{
+		"id",
+		"lat",
+		"lng",
+		"radius",
+		"place_id",
+		"name",
+		"type",
+		"status",
+		"note"}, "rank"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
 		}
-    default:
-		// Enter writer loop
-		lim := len(results)
-		for i := 0; i < lim; i++ {
-			// Extract current record from channel
-			record := <-results
-			// Format strings
-			latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
-			lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
-			// Print to stdout
-			values := []string{
-				record.Id,
-				record.Address,
-				latString,
-				lngString,
-				record.Note}
-			fmt.Println(strings.Join(values, ","))
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
+		lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
+		radiusString := strconv.Itoa(int(record.Radius))
+		rankString := strconv.Itoa(record.Rank)
+		// Write to output
+		err = out.Write(record, rankColumn(con, []string{
+			record.Id,
+			latString,
+			lngString,
+			radiusString,
+			record.PlaceId,
+			record.Name,
+			record.Type,
+			record.Status,
+			record.Note}, rankString), record.Extra)
+		if err != nil {
+			return err
 		}
 	}
 	return err
 }
 
-// CSV Writer for Generating Reverse Geocoding Output Results Files
-func ReverseGeocodeWriteOutput(con *cli.Context, results <-chan *GeocodeRecord) (e error) {
-	// Allocate empty error receiver
-    var err error = nil
-    // Switch on output file flag
-    switch con.IsSet("output") {
-    case true:
-        // Generate interface types
-        fp := &fileOutput{con.String("output")}
-        f, w := fp.Write()
-        // Defer closures
-        defer f.Close()
-        defer w.Flush()
-        // Format record outputs
-        err = w.Write([]string{
-            "id",
-            "lat",
-            "lng",
-            "address",
-            "note"})
-        if err != nil {
-            panic(err)
-        }
-        // Enter writer loop
-        lim := len(results)
-        for i := 0; i < lim; i++ {
-            // Extract current record from channel
-            record := <-results
-            // Format strings
-            latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
-            lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
-            // Write to output file
-            err := w.Write([]string{
-                record.Id,
-                latString,
-                lngString,
-                record.Address,
-                record.Note})
-            if err != nil {
-                panic(err)
-            }
-	    }
-    default:
-        // Enter writer loop
-        lim := len(results)
-        for i := 0; i < lim; i++ {
-            // Extract current record from channel
-            record := <-results
-            // Format strings
-            latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
-            lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
-            // Write to output file
-            values := []string{
-                record.Id,
-                latString,
-                lngString,
-                record.Address,
-                record.Note}
-            fmt.Println(strings.Join(values, ","))
-	    }
-    }
+// Writer for Generating Place Detail Output Results Files
+func PlaceDetailWriteOutput(con *cli.Context, results <-chan *PlaceRecord) (e error) {
+	// Open output writer
+	out, err := NewOutput(con, []string{
+		"id",
+		"place_id",
+		"name",
+		"formatted_address",
+		"phone",
+		"website",
+		"rating",
+		"user_ratings_total",
+		"opening_hours",
+		"types",
+		"lat",
+		"lng",
+		"viewport",
+		"bounds",
+		"status",
+		"note"})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
+		lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
+		ratingString := strconv.FormatFloat(float64(record.Detail.Rating), 'f', -1, 32)
+		totalString := strconv.Itoa(record.Detail.UserRatingsTotal)
+		// Write to output
+		err = out.Write(record, []string{
+			record.Id,
+			record.PlaceId,
+			record.Name,
+			record.Detail.FormattedAddress,
+			record.Detail.Phone,
+			record.Detail.Website,
+			ratingString,
+			totalString,
+			strings.Join(record.Detail.OpeningHours, "|"),
+			strings.Join(record.Detail.Types, "|"),
+			latString,
+			lngString,
+			boundsString(record.Viewport),
+			boundsString(record.Bounds),
+			record.Status,
+			record.Note}, record.Extra)
+		if err != nil {
+			return err
+		}
+	}
 	return err
 }
 
-// CSV Writer for Generating Places Nearby Output Results Files
-func PlaceNearbyWriteOutput(con *cli.Context, results <-chan *PlaceRecord) (e error) {
-	// Allocate empty error receiver
-    var err error = nil
-    // Switch on output file flag
-    switch con.IsSet("output") {
-    case true:
-        // Generate interface types
-        fp := &fileOutput{con.String("output")}
-        f, w := fp.Write()
-        // Defer closure
-        defer f.Close()
-        defer w.Flush()
-        // Format record outputs
-        err = w.Write([]string{
-            "id",
-            "lat",
-            "lng",
-            "radius",
-            "place_id",
-            "name",
-            "type",
-            "note"})
-        if err != nil {
-            panic(err)
-        }
-        // Enter writer loop
-        lim := len(results)
-        for i := 0; i < lim; i++ {
-            // Extract current record from channel
-            record := <-results
-            // Format strings
-            latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
-            lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
-            radiusString := strconv.Itoa(int(record.Radius))
-            // Write to output file
-            err := w.Write([]string{
-                record.Id,
-                latString,
-                lngString,
-                radiusString,
-                record.PlaceId,
-                record.Name,
-                record.Type,
-                record.Note})
-            if err != nil {
-                panic(err)
-            }
-        }
-    default:
-        // Enter writer loop
-        lim := len(results)
-        for i := 0; i < lim; i++ {
-            // Extract current record from channel
-            record := <-results
-            // Format strings
-            latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
-            lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
-            radiusString := strconv.Itoa(int(record.Radius))
-            // Write to output file
-            values := []string{
-                record.Id,
-                latString,
-                lngString,
-                radiusString,
-                record.PlaceId,
-                record.Name,
-                record.Type,
-                record.Note}
-            fmt.Println(strings.Join(values, ","))
-        }
-    }
+// Writer for Generating Place Search Output Results Files
+func PlaceSearchWriteOutput(con *cli.Context, results <-chan *PlaceRecord) (e error) {
+	// Open output writer
+	out, err := NewOutput(con, rankColumn(con, []string{
+		"id",
+		"query",
+		"place_id",
+		"name",
+		"formatted_address",
+		"lat",
+		"lng",
+		"rating",
+		"user_ratings_total",
+		"types",
+		"status",
+		"note"}, "rank"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
+		lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
+		ratingString := strconv.FormatFloat(float64(record.Detail.Rating), 'f', -1, 32)
+		totalString := strconv.Itoa(record.Detail.UserRatingsTotal)
+		rankString := strconv.Itoa(record.Rank)
+		// Write to output
+		err = out.Write(record, rankColumn(con, []string{
+			record.Id,
+			record.Query,
+			record.PlaceId,
+			record.Name,
+			record.Detail.FormattedAddress,
+			latString,
+			lngString,
+			ratingString,
+			totalString,
+			strings.Join(record.Detail.Types, "|"),
+			record.Status,
+			record.Note}, rankString), record.Extra)
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Writer for Generating Find Place Output Results Files
+func PlaceFindWriteOutput(con *cli.Context, results <-chan *PlaceRecord) (e error) {
+	// Open output writer
+	out, err := NewOutput(con, rankColumn(con, []string{
+		"id",
+		"query",
+		"place_id",
+		"name",
+		"formatted_address",
+		"lat",
+		"lng",
+		"types",
+		"status",
+		"note"}, "rank"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
+		lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
+		rankString := strconv.Itoa(record.Rank)
+		// Write to output
+		err = out.Write(record, rankColumn(con, []string{
+			record.Id,
+			record.Query,
+			record.PlaceId,
+			record.Name,
+			record.Detail.FormattedAddress,
+			latString,
+			lngString,
+			strings.Join(record.Detail.Types, "|"),
+			record.Status,
+			record.Note}, rankString), record.Extra)
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Writer for Generating Place Autocomplete Output Results Files
+func AutocompleteWriteOutput(con *cli.Context, results <-chan *AutocompleteRecord) (e error) {
+	// Open output writer
+	out, err := NewOutput(con, rankColumn(con, []string{
+		"id",
+		"input",
+		"session",
+		"place_id",
+		"description",
+		"main_text",
+		"secondary_text",
+		"types",
+		"matched_substrings",
+		"status",
+		"note"}, "rank"))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format matched substrings as offset:length pairs
+		var matched []string
+		for _, m := range record.Matched {
+			matched = append(matched, strconv.Itoa(m.Offset)+":"+strconv.Itoa(m.Length))
+		}
+		rankString := strconv.Itoa(record.Rank)
+		// Write to output
+		err = out.Write(record, rankColumn(con, []string{
+			record.Id,
+			record.Input,
+			record.Session,
+			record.PlaceId,
+			record.Description,
+			record.MainText,
+			record.SecondaryText,
+			strings.Join(record.Types, "|"),
+			strings.Join(matched, "|"),
+			record.Status,
+			record.Note}, rankString), record.Extra)
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Writer for Generating Directions Output Results Files
+func DirectionsWriteOutput(con *cli.Context, results <-chan *DirectionsRecord) (e error) {
+	// Open output writer
+	out, err := NewOutput(con, []string{
+		"id",
+		"origin",
+		"destination",
+		"mode",
+		"distance_m",
+		"duration_s",
+		"duration_in_traffic_s",
+		"summary",
+		"polyline",
+		"status",
+		"note"})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		distanceString := strconv.Itoa(record.Distance)
+		durationString := strconv.Itoa(record.Duration)
+		trafficString := strconv.Itoa(record.DurationInTraffic)
+		// Write to output
+		err = out.Write(record, []string{
+			record.Id,
+			record.Origin,
+			record.Destination,
+			record.Mode,
+			distanceString,
+			durationString,
+			trafficString,
+			record.Summary,
+			record.Polyline,
+			record.Status,
+			record.Note}, record.Extra)
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Write Distance Matrix Results to Output in Long or Wide Layout
+func MatrixWriteOutput(con *cli.Context, origins []*MatrixPoint, destinations []*MatrixPoint, results <-chan *MatrixRecord) (e error) {
+	// Check layout and value flags
+	layout := con.String("layout")
+	if layout != "long" && layout != "wide" {
+		return fmt.Errorf("gmaps: invalid layout %q", layout)
+	}
+	value := con.String("value")
+	if value != "duration" && value != "distance" {
+		return fmt.Errorf("gmaps: invalid matrix value %q", value)
+	}
+	if layout == "wide" {
+		return writeMatrixWide(con, origins, destinations, value, results)
+	}
+	// Open output writer
+	out, err := NewOutput(con, []string{
+		"origin_id",
+		"destination_id",
+		"distance_m",
+		"duration_s",
+		"duration_in_traffic_s",
+		"status",
+		"note"})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		distanceString := strconv.Itoa(record.Distance)
+		durationString := strconv.Itoa(record.Duration)
+		trafficString := strconv.Itoa(record.DurationInTraffic)
+		// Write to output
+		err = out.Write(record, []string{
+			record.OriginId,
+			record.DestinationId,
+			distanceString,
+			durationString,
+			trafficString,
+			record.Status,
+			record.Note}, Passthrough{})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Buffer Distance Matrix Results and Write One Row per Origin
+func writeMatrixWide(con *cli.Context, origins []*MatrixPoint, destinations []*MatrixPoint, value string, results <-chan *MatrixRecord) (e error) {
+	// Allocate cell grid
+	cells := make([][]string, len(origins))
+	for i := range cells {
+		cells[i] = make([]string, len(destinations))
+	}
+	// Fill cells as results arrive
+	for record := range results {
+		if record.Status != StatusOK {
+			continue
+		}
+		cell := record.Duration
+		if value == "distance" {
+			cell = record.Distance
+		}
+		cells[record.OriginIndex][record.DestinationIndex] = strconv.Itoa(cell)
+	}
+	// Build header from destination ids
+	header := []string{"origin_id"}
+	for _, d := range destinations {
+		header = append(header, d.Id)
+	}
+	// Open output writer
+	out, err := NewOutput(con, header)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for i, o := range origins {
+		row := append([]string{o.Id}, cells[i]...)
+		rec := make(map[string]string, len(row))
+		for j, h := range header {
+			rec[h] = row[j]
+		}
+		err = out.Write(rec, row, Passthrough{})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Writer for Generating Output Roads Snapped Point Files
+func RoadsWriteOutput(con *cli.Context, mode string, results <-chan *RoadRecord) (e error) {
+	// Add speed limit columns when requested
+	header := []string{
+		"trace_id",
+		"point_id",
+		"original_index",
+		"lat",
+		"lng",
+		"place_id"}
+	if mode == "speedlimits" {
+		header = append(header, "speed_limit", "units")
+	}
+	header = append(header, "status", "note")
+	// Open output writer
+	out, err := NewOutput(con, header)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Report close errors
+		if cerr := out.Close(); e == nil {
+			e = cerr
+		}
+	}()
+	// Enter writer loop
+	for record := range results {
+		// Format strings
+		indexString := ""
+		if record.OriginalIndex >= 0 {
+			indexString = strconv.Itoa(record.OriginalIndex)
+		}
+		latString := strconv.FormatFloat(record.Lat, 'f', -1, 64)
+		lngString := strconv.FormatFloat(record.Lng, 'f', -1, 64)
+		row := []string{
+			record.TraceId,
+			record.PointId,
+			indexString,
+			latString,
+			lngString,
+			record.PlaceId}
+		if mode == "speedlimits" {
+			limitString := ""
+			if len(record.Units) != 0 {
+				limitString = strconv.FormatFloat(record.SpeedLimit, 'f', -1, 64)
+			}
+			row = append(row, limitString, record.Units)
+		}
+		// Write to output
+		err = out.Write(record, append(row, record.Status, record.Note), record.Extra)
+		if err != nil {
+			return err
+		}
+	}
 	return err
 }
+
+// Format Bounds as Southwest and Northeast Corners
+func boundsString(b maps.LatLngBounds) (out string) {
+	// Skip empty bounds
+	if b.NorthEast == (maps.LatLng{}) && b.SouthWest == (maps.LatLng{}) {
+		return ""
+	}
+	return b.String()
+}
+
+// Extract Point Coordinates from a Result Record
+func recordPoint(rec interface{}) (lat float64, lng float64, ok bool) {
+	// Switch on record type
+	var status string
+	switch r := rec.(type) {
+	case *GeocodeRecord:
+		lat, lng, status = r.Lat, r.Lng, r.Status
+	case *ElevationRecord:
+		lat, lng, status = r.Lat, r.Lng, r.Status
+	case *TimezoneRecord:
+		lat, lng, status = r.Lat, r.Lng, r.Status
+	case *RoadRecord:
+		lat, lng, status = r.Lat, r.Lng, r.Status
+	case *PlaceRecord:
+		lat, lng, status = r.Lat, r.Lng, r.Status
+	default:
+		return 0, 0, false
+	}
+	// Skip unresolved records without coordinates
+	if status != StatusOK && lat == 0 && lng == 0 {
+		return 0, 0, false
+	}
+	return lat, lng, true
+}
+
+// Extract Display Name and Description from a Result Record
+func recordLabel(rec interface{}) (name string, desc string) {
+	// Switch on record type
+	var address, note string
+	switch r := rec.(type) {
+	case *GeocodeRecord:
+		name, address, note = r.Id, r.Address, r.Note
+	case *ElevationRecord:
+		name, note = r.Id, r.Note
+	case *TimezoneRecord:
+		name, address, note = r.Id, r.TimeZoneId, r.Note
+	case *RoadRecord:
+		name, address, note = r.TraceId, r.PlaceId, r.Note
+	case *PlaceRecord:
+		name, address, note = r.Id, r.Name, r.Note
+	case *AutocompleteRecord:
+		name, address, note = r.Id, r.Description, r.Note
+	case *DirectionsRecord:
+		name, address, note = r.Id, r.Summary, r.Note
+	}
+	// Join non-empty description parts
+	var parts []string
+	for _, part := range []string{address, note} {
+		if len(part) != 0 {
+			parts = append(parts, part)
+		}
+	}
+	return name, strings.Join(parts, "\n")
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"encoding/csv"
+	"fmt"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Rejects Struct Field Specification
+type Rejects struct {
+	file  *os.File
+	w     *csv.Writer
+	count int
+	err   error
+	mu    sync.Mutex
+}
+
+// Open Rejects File on Optional Context Flag
+func OpenRejects(con *cli.Context) (rej *Rejects, e error) {
+	// Allocate rejects counter
+	rej = &Rejects{}
+	// Skip file when rejects flag is not set
+	if con.IsSet("rejects") != true {
+		return rej, nil
+	}
+	// Open rejects file
+	f, err := os.Create(con.String("rejects"))
+	if err != nil {
+		return nil, err
+	}
+	rej.file = f
+	rej.w = csv.NewWriter(f)
+	// Write header row
+	err = rej.w.Write([]string{
+		"row",
+		"error",
+		"record"})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return rej, nil
+}
+
+// Record Rejected Input Row with Its Row Number and Parse Error
+func (r *Rejects) Reject(row int, record []string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.count++
+	// Print to stderr without a rejects file
+	if r.w == nil {
+		fmt.Fprintf(os.Stderr, "Row %d Rejected: %v\n", row, err)
+		return
+	}
+	// Write row number, error and original fields
+	r.w.Write(append([]string{strconv.Itoa(row), err.Error()}, record...))
+	r.w.Flush()
+}
+
+// Number of Rejected Input Rows
+func (r *Rejects) Count() (n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count
+}
+
+// Record an Input Read Failure that Truncated the Input
+func (r *Rejects) Fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Keep the first failure
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// Input Read Failure, if Any
+func (r *Rejects) Err() (e error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close Rejects File and Report Any Write Errors
+func (r *Rejects) Close() (e error) {
+	// Skip without a rejects file
+	if r.file == nil {
+		return nil
+	}
+	r.w.Flush()
+	err := r.w.Error()
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
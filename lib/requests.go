@@ -26,200 +26,931 @@ import (
 	"fmt"
 	"golang.org/x/net/context"
 	"googlemaps.github.io/maps"
-	"gopkg.in/cheggaaa/pb.v1"
 	"gopkg.in/urfave/cli.v1"
+	"strings"
+	"time"
 )
 
+// Places API Search Result Page Size and Page Limit
+const (
+	placePageSize = 20
+	placePageMax  = 3
+)
+
+// Distance Matrix Locations per Request Side
+const matrixSideMax = 25
+
+// Roads API Points per Request Window
+const roadsWindowMax = 100
+
+// Delay Before a Places API Next Page Token Becomes Valid
+var pageTokenDelay = 2 * time.Second
+
 // Wrapper Function to Automate the API Calls
 func GeocodeRecords(con *cli.Context, clt *maps.Client, records <-chan *GeocodeRecord) (results chan *GeocodeRecord, e error) {
-	// Allocate empty variables
-	var err error = nil
-	results = make(chan *GeocodeRecord, len(records))
-	lim := len(records)
-	bar := pb.StartNew(lim)
-	// Enter request loop
-	for i := 0; i < lim; i++ {
-		// Extract current record
-		rec := <-records
-		req := GeocodeFormatRequest(con, rec)
-		// Submit requests and process errors
-		if req.Address != "" {
-			res, err := clt.Geocode(context.Background(), &req)
-			if err != nil {
-				fmt.Println(err)
-			}
-			if len(res) != 0 {
-				rec.Lat = res[0].Geometry.Location.Lat
-				rec.Lng = res[0].Geometry.Location.Lng
-				rec.Note = "Success"
-			} else {
-				rec.Note = "No Geocoding Result"
+	return runRecords(con, records, func(rec *GeocodeRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *GeocodeRecord) ([]*GeocodeRecord, string) {
+		// Emit ranked candidates
+		out := geocodeRecord(con, clt, lmt, cch, rec)
+		return out, rec.Status
+	})
+}
+
+// Submit Geocoding API Call for a Single Record
+func geocodeRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *GeocodeRecord) (candidates []*GeocodeRecord) {
+	req := GeocodeFormatRequest(con, rec)
+	// Submit requests and process errors
+	if req.Address != "" || len(req.Components) != 0 {
+		var res []maps.GeocodingResult
+		// Normalize address for cache key
+		key := req
+		key.Address = NormalizeAddress(req.Address)
+		status, err := CachedRequest(con, lmt, cch, CacheGeocode, key, &res, func() (e error) {
+			res, e = clt.Geocode(context.Background(), &req)
+			return e
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if len(res) != 0 {
+			rec.Note = "Success"
+			// Copy ranked candidates from results
+			for i := 0; i < len(res) && i < MaxResults(con); i++ {
+				c := *rec
+				c.Rank = i + 1
+				c.Lat = res[i].Geometry.Location.Lat
+				c.Lng = res[i].Geometry.Location.Lng
+				c.Detail = GeocodeResultDetail(res[i])
+				candidates = append(candidates, &c)
 			}
+			return candidates
 		} else {
-			rec.Note = "Address Missing"
+			rec.Status = StatusZeroResults
+			rec.Note = "No Geocoding Result"
 		}
-		// Send results to channel
-		results <- rec
-		// Increment progress bar
-		bar.Increment()
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Address Missing"
 	}
-	// Finish progress bar
-	bar.Finish()
-	return results, err
+	return []*GeocodeRecord{rec}
+}
+
+// Extract Result Detail from a Geocoding API Result
+func GeocodeResultDetail(res maps.GeocodingResult) (detail GeocodeDetail) {
+	// Copy result properties
+	detail = GeocodeDetail{
+		FormattedAddress: res.FormattedAddress,
+		PlaceId:          res.PlaceID,
+		LocationType:     res.Geometry.LocationType,
+		PartialMatch:     res.PartialMatch,
+		Types:            res.Types,
+	}
+	// Parse address components by type
+	for _, c := range res.AddressComponents {
+		for _, t := range c.Types {
+			switch t {
+			case "street_number":
+				detail.StreetNumber = c.LongName
+			case "route":
+				detail.Route = c.LongName
+			case "locality":
+				detail.Locality = c.LongName
+			case "administrative_area_level_2":
+				detail.County = c.LongName
+			case "administrative_area_level_1":
+				detail.State = c.ShortName
+			case "postal_code":
+				detail.PostalCode = c.LongName
+			case "country":
+				detail.Country = c.ShortName
+			}
+		}
+	}
+	return detail
 }
 
 // Wrapper function to Automate Reverse Geocoding API Calls
 func ReverseGeocodeRecords(con *cli.Context, clt *maps.Client, records <-chan *GeocodeRecord) (results chan *GeocodeRecord, e error) {
-	// Allocate empty variables
-	var err error = nil
-    // Allocate receiver variables
-	lim := len(records)
-    results = make(chan *GeocodeRecord, lim)
-	bar := pb.StartNew(lim)
-	// Enter request loop
-	for i := 0; i < lim; i++ {
-		//Extract current record
-		rec := <-records
-		req := ReverseGeocodeFormatRequest(con, rec)
-		// Submit requests and process errors
-		if req.LatLng.Lat != 0 && req.LatLng.Lng != 0 {
-			res, err := clt.Geocode(context.Background(), &req)
-			if err != nil {
-				fmt.Println(err)
-			}
-			if len(res) != 0 {
-				rec.Address = res[0].FormattedAddress
-				rec.Note = "Success"
-			} else {
-				rec.Note = "No Reverse Geocoding Result"
-			}
+	return runRecords(con, records, func(rec *GeocodeRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *GeocodeRecord) ([]*GeocodeRecord, string) {
+		// Update record in place
+		reverseGeocodeRecord(con, clt, lmt, cch, rec)
+		return []*GeocodeRecord{rec}, rec.Status
+	})
+}
+
+// Submit Reverse Geocoding API Call for a Single Record
+func reverseGeocodeRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *GeocodeRecord) {
+	req := ReverseGeocodeFormatRequest(con, rec)
+	// Submit requests and process errors
+	if req.LatLng.Lat != 0 && req.LatLng.Lng != 0 {
+		var res []maps.GeocodingResult
+		status, err := CachedRequest(con, lmt, cch, CacheReverseGeocode, req, &res, func() (e error) {
+			res, e = clt.Geocode(context.Background(), &req)
+			return e
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if len(res) != 0 {
+			rec.Address = res[0].FormattedAddress
+			rec.Detail = GeocodeResultDetail(res[0])
+			rec.Note = "Success"
 		} else {
-			rec.Note = "Lat and/or Lng Missing"
+			rec.Status = StatusZeroResults
+			rec.Note = "No Reverse Geocoding Result"
 		}
-		// Send results to channel
-		results <- rec
-		// Increment progress bar
-		bar.Increment()
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Lat and/or Lng Missing"
 	}
-	// Finish progress bar
-	bar.Finish()
-	return results, err
 }
 
 // Wrapper Function to Automate Elevation API Calls
 func ElevationRecords(con *cli.Context, clt *maps.Client, records <-chan *ElevationRecord) (results chan *ElevationRecord, e error) {
-	// Allocate empty variables
-	var err error = nil
-    // Allocate receiver variables
-	lim := len(records)
-    results = make(chan *ElevationRecord, lim)
-	bar := pb.StartNew(lim)
-	// Enter request loop
-	for i := 0; i < lim; i++ {
-		// Extract current records
-		rec := <-records
-		req := ElevationFormatRequest(con, rec)
-		// Submit requests and process errors
-		if req.Locations[0].Lat != 0 && req.Locations[0].Lng != 0 {
-			res, err := clt.Elevation(context.Background(), &req)
-			if err != nil {
-				fmt.Println(err)
-			}
-			if len(res) != 0 {
-				rec.Elevation = res[0].Elevation
-				rec.Resolution = res[0].Resolution
-				rec.Note = "Success"
-			} else {
-				rec.Note = "No Elevation Result"
+	return runRecords(con, records, func(rec *ElevationRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *ElevationRecord) ([]*ElevationRecord, string) {
+		// Update record in place
+		elevationRecord(con, clt, lmt, cch, rec)
+		return []*ElevationRecord{rec}, rec.Status
+	})
+}
+
+// Submit Elevation API Call for a Single Record
+func elevationRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *ElevationRecord) {
+	req := ElevationFormatRequest(con, rec)
+	// Submit requests and process errors
+	if req.Locations[0].Lat != 0 && req.Locations[0].Lng != 0 {
+		var res []maps.ElevationResult
+		status, err := CachedRequest(con, lmt, cch, CacheElevation, req, &res, func() (e error) {
+			res, e = clt.Elevation(context.Background(), &req)
+			return e
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if len(res) != 0 {
+			rec.Elevation = res[0].Elevation
+			rec.Resolution = res[0].Resolution
+			rec.Note = "Success"
+		} else {
+			rec.Status = StatusZeroResults
+			rec.Note = "No Elevation Result"
+		}
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Latitude or Longitude Missing"
+	}
+}
+
+// Wrapper Function to Automate Time Zone API Calls
+func TimezoneRecords(con *cli.Context, clt *maps.Client, records <-chan *TimezoneRecord) (results chan *TimezoneRecord, e error) {
+	return runRecords(con, records, func(rec *TimezoneRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *TimezoneRecord) ([]*TimezoneRecord, string) {
+		// Update record in place
+		timezoneRecord(con, clt, lmt, cch, rec)
+		return []*TimezoneRecord{rec}, rec.Status
+	})
+}
+
+// Submit Time Zone API Call for a Single Record
+func timezoneRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *TimezoneRecord) {
+	req := TimezoneFormatRequest(con, rec)
+	// Submit requests and process errors
+	if rec.Lat != 0 && rec.Lng != 0 {
+		var res maps.TimezoneResult
+		status, err := CachedRequest(con, lmt, cch, CacheTimezone, req, &res, func() (e error) {
+			out, e := clt.Timezone(context.Background(), &req)
+			if e == nil {
+				res = *out
 			}
+			return e
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if len(res.TimeZoneID) != 0 {
+			rec.TimeZoneId = res.TimeZoneID
+			rec.TimeZoneName = res.TimeZoneName
+			rec.RawOffset = res.RawOffset
+			rec.DstOffset = res.DstOffset
+			rec.Note = "Success"
 		} else {
-			rec.Note = "Latitude or Longitude Missing"
+			rec.Status = StatusZeroResults
+			rec.Note = "No Time Zone Result"
 		}
-		// Send results to channel
-		results <- rec
-		// Increment progress bar
-		bar.Increment()
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Latitude or Longitude Missing"
 	}
-	// Finish progress bar
-	bar.Finish()
-	return results, err
 }
 
 // Wrapper Function to Automate Places API Nearby Calls
 func PlaceNearbyRecords(con *cli.Context, clt *maps.Client, records <-chan *PlaceRecord) (results chan *PlaceRecord, e error) {
-	// Allocate empty variables
-	var err error = nil
-    // Allocate receiver variables
-	lim := len(records)
-    results = make(chan *PlaceRecord, lim)
-	bar := pb.StartNew(lim)
-	// Enter request loop
-	for i := 0; i < lim; i++ {
-		// Extract current records
-		rec := <-records
-		req := PlaceNearbyFormatRequest(con, rec)
-		// Submit requests and process errors
-		if req.Location.Lat != 0 && req.Location.Lng != 0 {
-			res, err := clt.NearbySearch(context.Background(), &req)
+	return runRecords(con, records, func(rec *PlaceRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *PlaceRecord) ([]*PlaceRecord, string) {
+		// Emit ranked candidates
+		out := placeNearbyRecord(con, clt, lmt, cch, rec)
+		return out, rec.Status
+	})
+}
+
+// Submit Places API Nearby Call for a Single Record
+func placeNearbyRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *PlaceRecord) (candidates []*PlaceRecord) {
+	req := PlaceNearbyFormatRequest(con, rec)
+	// Submit requests and process errors
+	if req.Location.Lat != 0 && req.Location.Lng != 0 {
+		// Key cached results on request and page count
+		pager := &searchPager{pages: SearchPages(con)}
+		key := struct {
+			maps.NearbySearchRequest
+			Pages int
+		}{req, pager.pages}
+		var res []maps.PlacesSearchResult
+		// Retried attempts resume at the failed page
+		status, err := CachedRequest(con, lmt, cch, CachePlaceNearby, key, &res, func() (e error) {
+			e = pager.fetch(lmt, func(token string) (maps.PlacesSearchResponse, error) {
+				page := req
+				page.PageToken = token
+				return clt.NearbySearch(context.Background(), &page)
+			})
+			res = pager.results
+			return e
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if len(res) != 0 {
+			// Copy ranked candidates from results
+			for i := 0; i < len(res) && i < MaxResults(con); i++ {
+				candidates = append(candidates, PlaceCandidate(rec, i+1, res[i]))
+			}
+			// Note how many candidates are emitted
+			note := CandidateNote(len(res), len(candidates), "Place Results")
+			for _, c := range candidates {
+				c.Note = note
+			}
+			return candidates
+		} else {
+			rec.Status = StatusZeroResults
+			rec.Note = "No Place Result"
+		}
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Latitude or Longitude Missing"
+	}
+	return []*PlaceRecord{rec}
+}
+
+// Copy a Ranked Places API Search Result and Its Geometry onto an Input Record
+func PlaceCandidate(rec *PlaceRecord, rank int, res maps.PlacesSearchResult) (candidate *PlaceRecord) {
+	c := *rec
+	c.Rank = rank
+	c.PlaceId = res.PlaceID
+	c.Name = res.Name
+	c.Lat = res.Geometry.Location.Lat
+	c.Lng = res.Geometry.Location.Lng
+	c.Viewport = res.Geometry.Viewport
+	if len(res.Types) != 0 {
+		c.Type = res.Types[0]
+	}
+	c.Detail = PlaceDetail{
+		FormattedAddress: res.FormattedAddress,
+		Rating:           res.Rating,
+		UserRatingsTotal: res.UserRatingsTotal,
+		Types:            res.Types,
+	}
+	return &c
+}
+
+// Wrapper Function to Automate Places API Detail Calls
+func PlaceDetailRecords(con *cli.Context, clt *maps.Client, records <-chan *PlaceRecord) (results chan *PlaceRecord, e error) {
+	return runRecords(con, records, func(rec *PlaceRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *PlaceRecord) ([]*PlaceRecord, string) {
+		// Update record in place
+		placeDetailRecord(con, clt, lmt, cch, rec)
+		return []*PlaceRecord{rec}, rec.Status
+	})
+}
+
+// Submit Places API Detail Call for a Single Record
+func placeDetailRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *PlaceRecord) {
+	req := PlaceDetailFormatRequest(con, rec)
+	// Submit requests and process errors
+	if req.PlaceID != "" {
+		var res maps.PlaceDetailsResult
+		status, err := CachedRequest(con, lmt, cch, CachePlaceDetail, req, &res, func() (e error) {
+			res, e = clt.PlaceDetails(context.Background(), &req)
+			return e
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else {
+			rec.Name = res.Name
+			if len(res.Types) != 0 {
+				rec.Type = res.Types[0]
+			}
+			rec.Lat = res.Geometry.Location.Lat
+			rec.Lng = res.Geometry.Location.Lng
+			rec.Viewport = res.Geometry.Viewport
+			rec.Bounds = res.Geometry.Bounds
+			rec.Detail = PlaceResultDetail(res)
+			rec.Note = "Success"
+		}
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Place ID Missing"
+	}
+}
+
+// Extract Place Detail from a Places API Detail Result
+func PlaceResultDetail(res maps.PlaceDetailsResult) (detail PlaceDetail) {
+	// Copy result properties
+	detail = PlaceDetail{
+		FormattedAddress: res.FormattedAddress,
+		Phone:            res.FormattedPhoneNumber,
+		Website:          res.Website,
+		Rating:           res.Rating,
+		UserRatingsTotal: res.UserRatingsTotal,
+		Types:            res.Types,
+	}
+	// Extract weekly opening hours
+	if res.OpeningHours != nil {
+		detail.OpeningHours = res.OpeningHours.WeekdayText
+	}
+	return detail
+}
+
+// Wrapper Function to Automate Places API Text Search Calls
+func PlaceSearchRecords(con *cli.Context, clt *maps.Client, records <-chan *PlaceRecord) (results chan *PlaceRecord, e error) {
+	return runRecords(con, records, func(rec *PlaceRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *PlaceRecord) ([]*PlaceRecord, string) {
+		// Emit ranked candidates
+		out := placeSearchRecord(con, clt, lmt, cch, rec)
+		return out, rec.Status
+	})
+}
+
+// Submit Places API Text Search Calls for a Single Record
+func placeSearchRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *PlaceRecord) (candidates []*PlaceRecord) {
+	req := PlaceSearchFormatRequest(con, rec)
+	// Submit requests and process errors
+	if len(req.Query) != 0 {
+		// Key cached results on request and page count
+		pager := &searchPager{pages: SearchPages(con)}
+		key := struct {
+			maps.TextSearchRequest
+			Pages int
+		}{req, pager.pages}
+		var res []maps.PlacesSearchResult
+		// Retried attempts resume at the failed page
+		status, err := CachedRequest(con, lmt, cch, CachePlaceSearch, key, &res, func() (e error) {
+			e = pager.fetch(lmt, func(token string) (maps.PlacesSearchResponse, error) {
+				page := req
+				page.PageToken = token
+				return clt.TextSearch(context.Background(), &page)
+			})
+			res = pager.results
+			return e
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if len(res) != 0 {
+			// Copy ranked candidates from results
+			for i := 0; i < len(res) && i < MaxResults(con); i++ {
+				candidates = append(candidates, PlaceCandidate(rec, i+1, res[i]))
+			}
+			// Note how many candidates are emitted
+			note := CandidateNote(len(res), len(candidates), "Place Results")
+			for _, c := range candidates {
+				c.Note = note
+			}
+			return candidates
+		} else {
+			rec.Status = StatusZeroResults
+			rec.Note = "No Place Result"
+		}
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Search Query Missing"
+	}
+	return []*PlaceRecord{rec}
+}
+
+// Wrapper Function to Automate Places API Find Place Calls
+func PlaceFindRecords(con *cli.Context, clt *maps.Client, records <-chan *PlaceRecord) (results chan *PlaceRecord, e error) {
+	return runRecords(con, records, func(rec *PlaceRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *PlaceRecord) ([]*PlaceRecord, string) {
+		// Emit ranked candidates
+		out := placeFindRecord(con, clt, lmt, cch, rec)
+		return out, rec.Status
+	})
+}
+
+// Submit Places API Find Place Call for a Single Record
+func placeFindRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *PlaceRecord) (candidates []*PlaceRecord) {
+	req := PlaceFindFormatRequest(con, rec)
+	// Submit requests and process errors
+	if len(req.Input) != 0 {
+		var res maps.FindPlaceFromTextResponse
+		status, err := CachedRequest(con, lmt, cch, CachePlaceFind, req, &res, func() (e error) {
+			res, e = clt.FindPlaceFromText(context.Background(), &req)
+			return e
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if len(res.Candidates) != 0 {
+			// Copy ranked candidates from results
+			for i := 0; i < len(res.Candidates) && i < MaxResults(con); i++ {
+				candidates = append(candidates, PlaceCandidate(rec, i+1, res.Candidates[i]))
+			}
+			// Note how many candidates are emitted
+			note := CandidateNote(len(res.Candidates), len(candidates), "Place Results")
+			for _, c := range candidates {
+				c.Note = note
+			}
+			return candidates
+		} else {
+			rec.Status = StatusZeroResults
+			rec.Note = "No Place Result"
+		}
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Find Place Input Missing"
+	}
+	return []*PlaceRecord{rec}
+}
+
+// Wrapper Function to Automate Places API Autocomplete Calls
+func AutocompleteRecords(con *cli.Context, clt *maps.Client, records <-chan *AutocompleteRecord) (results chan *AutocompleteRecord, e error) {
+	return runRecords(con, records, func(rec *AutocompleteRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *AutocompleteRecord) ([]*AutocompleteRecord, string) {
+		// Emit ranked candidates
+		out := autocompleteRecord(con, clt, lmt, cch, rec)
+		return out, rec.Status
+	})
+}
+
+// Submit Places API Autocomplete Call for a Single Record
+func autocompleteRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *AutocompleteRecord) (candidates []*AutocompleteRecord) {
+	// Submit requests and process errors
+	if len(rec.Input) != 0 {
+		var res maps.AutocompleteResponse
+		var status string
+		var err error = nil
+		// Switch on prediction mode
+		switch con.String("mode") {
+		case "query":
+			req := QueryAutocompleteFormatRequest(con, rec)
+			status, err = CachedRequest(con, lmt, cch, CacheQueryComplete, req, &res, func() (e error) {
+				res, e = clt.QueryAutocomplete(context.Background(), &req)
+				return e
+			})
+		default:
+			// Key cached results without the session token
+			req := AutocompleteFormatRequest(con, rec)
+			key := req
+			key.SessionToken = maps.PlaceAutocompleteSessionToken{}
+			status, err = CachedRequest(con, lmt, cch, CacheAutocomplete, key, &res, func() (e error) {
+				res, e = clt.PlaceAutocomplete(context.Background(), &req)
+				return e
+			})
+		}
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if len(res.Predictions) != 0 {
+			// Copy ranked candidates from predictions
+			for i := 0; i < len(res.Predictions) && i < MaxResults(con); i++ {
+				p := res.Predictions[i]
+				c := *rec
+				c.Rank = i + 1
+				c.PlaceId = p.PlaceID
+				c.Description = p.Description
+				c.MainText = p.StructuredFormatting.MainText
+				c.SecondaryText = p.StructuredFormatting.SecondaryText
+				c.Types = p.Types
+				c.Matched = p.MatchedSubstrings
+				candidates = append(candidates, &c)
+			}
+			// Note how many predictions are emitted
+			note := CandidateNote(len(res.Predictions), len(candidates), "Predictions")
+			for _, c := range candidates {
+				c.Note = note
+			}
+			return candidates
+		} else {
+			rec.Status = StatusZeroResults
+			rec.Note = "No Autocomplete Prediction"
+		}
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Autocomplete Input Missing"
+	}
+	return []*AutocompleteRecord{rec}
+}
+
+// Number of Places API Result Pages Needed for the Requested Results
+func SearchPages(con *cli.Context) (pages int) {
+	pages = (MaxResults(con) + placePageSize - 1) / placePageSize
+	if pages > placePageMax {
+		pages = placePageMax
+	}
+	return pages
+}
+
+// Places API Search Results Collected Across Next Page Tokens
+type searchPager struct {
+	pages   int
+	page    int
+	token   string
+	results []maps.PlacesSearchResult
+}
+
+// Fetch Remaining Result Pages, Resuming at the Page of a Failed Attempt
+func (p *searchPager) fetch(lmt *RateLimiter, search func(token string) (maps.PlacesSearchResponse, error)) (e error) {
+	for {
+		// Submit page request
+		res, err := search(p.token)
+		// Retry next page tokens which are not yet valid
+		for attempt := 0; err != nil && len(p.token) != 0 && ErrorStatus(err) == StatusInvalidRequest && attempt < 3; attempt++ {
+			time.Sleep(pageTokenDelay)
+			if err := lmt.Wait(); err != nil {
+				return err
+			}
+			res, err = search(p.token)
+		}
+		if err != nil {
+			return err
+		}
+		p.results = append(p.results, res.Results...)
+		p.page++
+		// Stop on last requested or available page
+		if p.page >= p.pages || len(res.NextPageToken) == 0 {
+			return nil
+		}
+		p.token = res.NextPageToken
+		// Wait for next page token and query budget
+		time.Sleep(pageTokenDelay)
+		if err := lmt.Wait(); err != nil {
+			return err
+		}
+	}
+}
+
+// Wrapper Function to Automate Directions API Calls
+func DirectionsRecords(con *cli.Context, clt *maps.Client, records <-chan *DirectionsRecord) (results chan *DirectionsRecord, e error) {
+	return runRecords(con, records, func(rec *DirectionsRecord) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *DirectionsRecord) ([]*DirectionsRecord, string) {
+		// Update record in place
+		directionsRecord(con, clt, lmt, cch, rec)
+		return []*DirectionsRecord{rec}, rec.Status
+	})
+}
+
+// Directions Route Totals Cached per Request
+type directionsResult struct {
+	Routes            int    `json:"routes"`
+	Distance          int    `json:"distance_m"`
+	Duration          int    `json:"duration_s"`
+	DurationInTraffic int    `json:"duration_in_traffic_s"`
+	Summary           string `json:"summary"`
+	Polyline          string `json:"polyline"`
+}
+
+// Submit Directions API Call for a Single Record
+func directionsRecord(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, rec *DirectionsRecord) {
+	req := DirectionsFormatRequest(con, rec)
+	// Skip cache for live traffic requests
+	if req.DepartureTime == "now" {
+		cch = nil
+	}
+	// Submit requests and process errors
+	if len(req.Origin) != 0 && len(req.Destination) != 0 {
+		var res directionsResult
+		status, err := CachedRequest(con, lmt, cch, CacheDirections, req, &res, func() (e error) {
+			routes, _, e := clt.Directions(context.Background(), &req)
+			if e != nil || len(routes) == 0 {
+				return e
+			}
+			// Total first route legs
+			res = directionsResult{
+				Routes:   len(routes),
+				Summary:  routes[0].Summary,
+				Polyline: routes[0].OverviewPolyline.Points,
+			}
+			for _, leg := range routes[0].Legs {
+				res.Distance += leg.Distance.Meters
+				res.Duration += int(leg.Duration.Seconds())
+				res.DurationInTraffic += int(leg.DurationInTraffic.Seconds())
+			}
+			return nil
+		})
+		rec.Status = status
+		if err != nil {
+			rec.Note = err.Error()
+		} else if res.Routes != 0 {
+			rec.Distance = res.Distance
+			rec.Duration = res.Duration
+			rec.DurationInTraffic = res.DurationInTraffic
+			rec.Summary = res.Summary
+			rec.Polyline = res.Polyline
+			rec.Note = "Success"
+		} else {
+			rec.Status = StatusZeroResults
+			rec.Note = "No Route Found"
+		}
+	} else {
+		rec.Status = StatusMissingInput
+		rec.Note = "Origin and/or Destination Missing"
+	}
+}
+
+// Split Distance Matrix Origins and Destinations into Chunks Within Element Limits
+func MatrixChunkSize(con *cli.Context, origins, destinations int) (originChunk int, destinationChunk int) {
+	// Parse element limit
+	elements := con.Int("max-elements")
+	if elements < 1 {
+		elements = 1
+	}
+	// Fill destinations first then origins
+	destinationChunk = destinations
+	if destinationChunk > matrixSideMax {
+		destinationChunk = matrixSideMax
+	}
+	if destinationChunk > elements {
+		destinationChunk = elements
+	}
+	if destinationChunk < 1 {
+		destinationChunk = 1
+	}
+	originChunk = elements / destinationChunk
+	if originChunk > matrixSideMax {
+		originChunk = matrixSideMax
+	}
+	return originChunk, destinationChunk
+}
+
+// Wrapper Function to Automate Distance Matrix API Calls
+func MatrixRecords(con *cli.Context, clt *maps.Client, origins []*MatrixPoint, destinations []*MatrixPoint) (results chan *MatrixRecord, e error) {
+	// Split origins and destinations into request blocks
+	blocks := make(chan *matrixBlock, channelBuffer)
+	oc, dc := MatrixChunkSize(con, len(origins), len(destinations))
+	go func() {
+		defer close(blocks)
+		for oi := 0; oi < len(origins); oi += oc {
+			for di := 0; di < len(destinations); di += dc {
+				// Slice current chunk
+				oe, de := oi+oc, di+dc
+				if oe > len(origins) {
+					oe = len(origins)
+				}
+				if de > len(destinations) {
+					de = len(destinations)
+				}
+				blocks <- &matrixBlock{
+					Key:              fmt.Sprintf("%d-%d:%d-%d", oi, oe, di, de),
+					OriginIndex:      oi,
+					DestinationIndex: di,
+					Origins:          origins[oi:oe],
+					Destinations:     destinations[di:de],
+				}
+			}
+		}
+	}()
+	return runRecords(con, blocks, func(blk *matrixBlock) string { return blk.Key }, func(lmt *RateLimiter, cch *Cache, blk *matrixBlock) ([]*MatrixRecord, string) {
+		// Expand block into element records
+		return matrixChunk(con, clt, lmt, cch, blk)
+	})
+}
+
+// Distance Matrix Request Block Struct Field Specification
+type matrixBlock struct {
+	Key              string
+	OriginIndex      int
+	DestinationIndex int
+	Origins          []*MatrixPoint
+	Destinations     []*MatrixPoint
+}
+
+// Distance Matrix Element Totals Cached per Request
+type matrixElement struct {
+	Status            string `json:"status"`
+	Distance          int    `json:"distance_m"`
+	Duration          int    `json:"duration_s"`
+	DurationInTraffic int    `json:"duration_in_traffic_s"`
+}
+
+// Submit Distance Matrix API Call for a Single Chunk
+func matrixChunk(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, blk *matrixBlock) (records []*MatrixRecord, status string) {
+	oi, di := blk.OriginIndex, blk.DestinationIndex
+	origins, destinations := blk.Origins, blk.Destinations
+	req := MatrixFormatRequest(con, origins, destinations)
+	// Skip cache for live traffic requests
+	if req.DepartureTime == "now" {
+		cch = nil
+	}
+	// Submit requests and process errors
+	var res [][]matrixElement
+	status, err := CachedRequest(con, lmt, cch, CacheMatrix, req, &res, func() (e error) {
+		resp, e := clt.DistanceMatrix(context.Background(), &req)
+		if e != nil {
+			return e
+		}
+		// Copy element totals by row
+		res = make([][]matrixElement, len(resp.Rows))
+		for i, row := range resp.Rows {
+			for _, el := range row.Elements {
+				res[i] = append(res[i], matrixElement{
+					Status:            el.Status,
+					Distance:          el.Distance.Meters,
+					Duration:          int(el.Duration.Seconds()),
+					DurationInTraffic: int(el.DurationInTraffic.Seconds()),
+				})
+			}
+		}
+		return nil
+	})
+	// Expand chunk into origin and destination pairs
+	for i, o := range origins {
+		for j, d := range destinations {
+			rec := &MatrixRecord{
+				OriginId:         o.Id,
+				DestinationId:    d.Id,
+				OriginIndex:      oi + i,
+				DestinationIndex: di + j,
+				Origin:           o.Location,
+				Destination:      d.Location,
+				Status:           status,
+			}
 			if err != nil {
-				fmt.Println(err)
-			}
-			if len(res.Results) != 0 {
-				rec.PlaceId = res.Results[0].PlaceID
-				rec.Name = res.Results[0].Name
-				rec.Type = res.Results[0].Types[0]
-				if len(res.Results) > 1 {
-					rec.Note = "Success: Multiple Place Results Found - First Retrieved"
-				} else {
+				rec.Note = err.Error()
+			} else if i < len(res) && j < len(res[i]) {
+				rec.Status = res[i][j].Status
+				rec.Distance = res[i][j].Distance
+				rec.Duration = res[i][j].Duration
+				rec.DurationInTraffic = res[i][j].DurationInTraffic
+				if rec.Status == StatusOK {
 					rec.Note = "Success"
+				} else {
+					rec.Note = "No Route Found"
 				}
 			} else {
-				rec.Note = "No Place Result"
+				rec.Status = StatusZeroResults
+				rec.Note = "Element Missing"
 			}
-		} else {
-			rec.Note = "Latitude or Longitude Missing"
-		}
-		// Send results to channel
-		results <- rec
-		// Increment progress bar
-		bar.Increment()
-	}
-	// Finish progress bar
-	bar.Finish()
-	return results, err
-}
-
-func PlaceDetailRecords(con *cli.Context, clt *maps.Client, records <-chan *PlaceRecord) (results chan *PlaceRecord, e error){
-    // Allocate empty variables
-    var err error = nil
-    // Allocate reciever variables
-    lim := len(records)
-    results = make(chan *PlaceRecord, lim)
-    bar := pb.StartNew(lim)
-    // Enter request loop
-    for i := 0; i < lim; i++ {
-        rec := <-records
-        req := PlaceDetailFormatRequest(con, rec)
-        // Submit requests and process errors
-        if req.PlaceID != "" {
-            res, err := clt.PlaceDetails(context.Background(), &req)
-            if err != nil {
-                fmt.Println(err)
-            }
-                rec.Name = res.Name
-                rec.Scope = res.Scope
-                rec.Type = res.Types[0]
-                rec.Viewport = res.Geometry.Viewport
-                rec.Bounds = res.Geometry.Bounds
-        } else {
-            rec.Note = "Place ID Missing"
-        }
-        // Send results to channel
-        results <- rec
-        // Increment progress bar
-        bar.Increment()
-    }
-    // Finish progress bar
-    bar.Finish()
-    return results, err
+			records = append(records, rec)
+		}
+	}
+	return records, status
+}
+
+// Wrapper Function to Automate Roads API Calls for Snap, Nearest or Speed Limit Modes
+func RoadsRecords(con *cli.Context, clt *maps.Client, mode string, records <-chan *RoadTrace) (results chan *RoadRecord, e error) {
+	return runRecords(con, records, func(rec *RoadTrace) string { return rec.Id }, func(lmt *RateLimiter, cch *Cache, rec *RoadTrace) ([]*RoadRecord, string) {
+		// Emit snapped points for the whole trace
+		return roadsTrace(con, clt, lmt, cch, mode, rec)
+	})
+}
+
+// Drop Snapped Points Already Emitted by the Previous Overlapping Window
+func trimOverlap(snapped []maps.SnappedPoint, start int, overlap int) (out []maps.SnappedPoint) {
+	// Keep every point of the first window
+	if start == 0 || overlap == 0 {
+		return snapped
+	}
+	// Cut after the last point snapped from the shared input points
+	cut := 0
+	for i, sp := range snapped {
+		if sp.OriginalIndex != nil && *sp.OriginalIndex < overlap {
+			cut = i + 1
+		}
+	}
+	return snapped[cut:]
+}
+
+// Roads API Response Fields Cached per Window
+type roadsResult struct {
+	SnappedPoints []maps.SnappedPoint `json:"snapped_points"`
+	SpeedLimits   []maps.SpeedLimit   `json:"speed_limits"`
+}
+
+// Submit Roads API Calls for Each Window of a Single Trace
+func roadsTrace(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, mode string, trace *RoadTrace) (records []*RoadRecord, status string) {
+	// Nearest roads points are independent so windows need no overlap
+	overlap := con.Int("overlap")
+	if mode == "nearest" {
+		overlap = 0
+	}
+	status = StatusOK
+	for _, start := range RoadsWindows(len(trace.Points), overlap) {
+		// Slice current window
+		end := start + roadsWindowMax
+		if end > len(trace.Points) {
+			end = len(trace.Points)
+		}
+		path := make([]maps.LatLng, 0, end-start)
+		for _, p := range trace.Points[start:end] {
+			path = append(path, maps.LatLng{Lat: p.Lat, Lng: p.Lng})
+		}
+		// Submit requests and process errors
+		res, wstatus, err := roadsWindow(con, clt, lmt, cch, mode, path)
+		if err != nil {
+			// Mark every input point of the failed trace
+			records = records[:0]
+			for i, p := range trace.Points {
+				records = append(records, &RoadRecord{
+					TraceId:       trace.Id,
+					PointId:       p.Id,
+					OriginalIndex: i,
+					Lat:           p.Lat,
+					Lng:           p.Lng,
+					Status:        wstatus,
+					Note:          err.Error(),
+					Extra:         p.Extra,
+				})
+			}
+			return records, wstatus
+		}
+		// Drop points already snapped by the previous window
+		snapped := trimOverlap(res.SnappedPoints, start, overlap)
+		// Index speed limits by place id
+		limits := make(map[string]maps.SpeedLimit, len(res.SpeedLimits))
+		for _, l := range res.SpeedLimits {
+			limits[l.PlaceID] = l
+		}
+		// Convert snapped points to records
+		for _, sp := range snapped {
+			rec := &RoadRecord{
+				TraceId:       trace.Id,
+				OriginalIndex: -1,
+				Lat:           sp.Location.Lat,
+				Lng:           sp.Location.Lng,
+				PlaceId:       sp.PlaceID,
+				Status:        StatusOK,
+				Note:          "Interpolated",
+			}
+			if sp.OriginalIndex != nil {
+				rec.OriginalIndex = start + *sp.OriginalIndex
+				rec.PointId = trace.Points[rec.OriginalIndex].Id
+				rec.Extra = trace.Points[rec.OriginalIndex].Extra
+				rec.Note = "Success"
+			}
+			if l, ok := limits[sp.PlaceID]; ok {
+				rec.SpeedLimit = l.SpeedLimit
+				rec.Units = string(l.Units)
+			}
+			records = append(records, rec)
+		}
+	}
+	// Report traces without any snapped points
+	if len(records) == 0 {
+		status = StatusZeroResults
+		records = append(records, &RoadRecord{
+			TraceId:       trace.Id,
+			OriginalIndex: -1,
+			Status:        status,
+			Note:          "No Road Found",
+		})
+	}
+	return records, status
+}
+
+// Submit Roads API Call for a Single Window
+func roadsWindow(con *cli.Context, clt *maps.Client, lmt *RateLimiter, cch *Cache, mode string, path []maps.LatLng) (result roadsResult, status string, e error) {
+	var res roadsResult
+	var err error
+	switch mode {
+	case "nearest":
+		req := maps.NearestRoadsRequest{Points: path}
+		status, err = CachedRequest(con, lmt, cch, CacheNearestRoads, req, &res, func() (e error) {
+			out, e := clt.NearestRoads(context.Background(), &req)
+			if e == nil {
+				res.SnappedPoints = out.SnappedPoints
+			}
+			return e
+		})
+	case "speedlimits":
+		req := maps.SpeedLimitsRequest{
+			Path:  path,
+			Units: maps.SpeedLimitKPH,
+		}
+		if strings.ToUpper(con.String("units")) == maps.SpeedLimitMPH {
+			req.Units = maps.SpeedLimitMPH
+		}
+		status, err = CachedRequest(con, lmt, cch, CacheSpeedLimits, req, &res, func() (e error) {
+			out, e := clt.SpeedLimits(context.Background(), &req)
+			if e == nil {
+				res.SnappedPoints = out.SnappedPoints
+				res.SpeedLimits = out.SpeedLimits
+			}
+			return e
+		})
+	default:
+		req := maps.SnapToRoadRequest{
+			Path:        path,
+			Interpolate: con.Bool("interpolate"),
+		}
+		status, err = CachedRequest(con, lmt, cch, CacheSnapToRoads, req, &res, func() (e error) {
+			out, e := clt.SnapToRoad(context.Background(), &req)
+			if e == nil {
+				res.SnappedPoints = out.SnappedPoints
+			}
+			return e
+		})
+	}
+	return res, status, err
 }
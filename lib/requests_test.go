@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"googlemaps.github.io/maps"
+)
+
+func TestMatrixChunkSize(t *testing.T) {
+	tests := []struct {
+		name         string
+		elements     int
+		origins      int
+		destinations int
+		wantOrigins  int
+		wantDests    int
+	}{
+		{"small matrix", 100, 3, 3, 25, 3},
+		{"square at element limit", 100, 100, 100, 4, 25},
+		{"wide destinations", 100, 5, 40, 4, 25},
+		{"single pair", 100, 1, 1, 25, 1},
+		{"lower element limit", 25, 10, 10, 2, 10},
+		{"element limit below destinations", 10, 50, 50, 1, 10},
+		{"one element", 1, 5, 5, 1, 1},
+		{"invalid element limit", 0, 5, 5, 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			con := testContext(t, map[string]string{"max-elements": strconv.Itoa(tt.elements)})
+			oc, dc := MatrixChunkSize(con, tt.origins, tt.destinations)
+			if oc != tt.wantOrigins || dc != tt.wantDests {
+				t.Fatalf("MatrixChunkSize() = (%d, %d), want (%d, %d)", oc, dc, tt.wantOrigins, tt.wantDests)
+			}
+			// Chunks never exceed per side or per request limits
+			if oc > matrixSideMax || dc > matrixSideMax || (tt.elements > 0 && oc*dc > tt.elements) {
+				t.Errorf("chunk %dx%d exceeds limits", oc, dc)
+			}
+		})
+	}
+}
+
+func TestTrimOverlap(t *testing.T) {
+	// Build snapped points from original indexes with -1 for interpolated points
+	points := func(indexes ...int) (out []maps.SnappedPoint) {
+		for _, i := range indexes {
+			sp := maps.SnappedPoint{PlaceID: strconv.Itoa(i)}
+			if i >= 0 {
+				idx := i
+				sp.OriginalIndex = &idx
+			}
+			out = append(out, sp)
+		}
+		return out
+	}
+	tests := []struct {
+		name    string
+		snapped []maps.SnappedPoint
+		start   int
+		overlap int
+		want    []maps.SnappedPoint
+	}{
+		{"first window kept", points(0, 1, 2), 0, 2, points(0, 1, 2)},
+		{"no overlap kept", points(0, 1, 2), 100, 0, points(0, 1, 2)},
+		{"shared points dropped", points(0, 1, 2, 3), 90, 2, points(2, 3)},
+		{"interpolated shared points dropped", points(0, -1, 1, -1, 2), 90, 2, points(-1, 2)},
+		{"unsnapped shared points", points(2, 3), 90, 2, points(2, 3)},
+		{"all shared", points(0, 1), 90, 2, points()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := trimOverlap(tt.snapped, tt.start, tt.overlap)
+			if len(got) != len(tt.want) {
+				t.Fatalf("trimOverlap() kept %d points, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].PlaceID != tt.want[i].PlaceID {
+					t.Errorf("point %d = %s, want %s", i, got[i].PlaceID, tt.want[i].PlaceID)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchPagerResume(t *testing.T) {
+	pageTokenDelay = 0
+	// Serve three pages with one stale token and one transient failure
+	calls := make(map[string]int)
+	search := func(token string) (res maps.PlacesSearchResponse, e error) {
+		calls[token]++
+		switch {
+		case token == "":
+			res.NextPageToken = "p2"
+		case token == "p2" && calls[token] == 1:
+			return res, errors.New("maps: INVALID_REQUEST - token not ready")
+		case token == "p2" && calls[token] == 2:
+			return res, errors.New("maps: UNKNOWN_ERROR - backend failure")
+		case token == "p2":
+			res.NextPageToken = "p3"
+		}
+		res.Results = []maps.PlacesSearchResult{{PlaceID: token}}
+		return res, nil
+	}
+	pager := &searchPager{pages: 3}
+	lmt := &RateLimiter{}
+	status, err := SubmitRequest(testContext(t, map[string]string{"max-retries": "1"}), lmt, func() error {
+		return pager.fetch(lmt, search)
+	})
+	if err != nil || status != StatusOK {
+		t.Fatalf("SubmitRequest() = %s, %v, want OK", status, err)
+	}
+	// Retried attempts resume at the failed page
+	if calls[""] != 1 || calls["p2"] != 3 || calls["p3"] != 1 {
+		t.Errorf("page calls = %v, want first and last page once", calls)
+	}
+	if len(pager.results) != 3 {
+		t.Errorf("collected %d results, want 3", len(pager.results))
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"golang.org/x/net/context"
+	"gopkg.in/urfave/cli.v1"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// API Request Status Codes
+const (
+	StatusOK                   = "OK"
+	StatusZeroResults          = "ZERO_RESULTS"
+	StatusNotFound             = "NOT_FOUND"
+	StatusOverQueryLimit       = "OVER_QUERY_LIMIT"
+	StatusOverDailyLimit       = "OVER_DAILY_LIMIT"
+	StatusRequestDenied        = "REQUEST_DENIED"
+	StatusInvalidRequest       = "INVALID_REQUEST"
+	StatusMaxElementsExceeded  = "MAX_ELEMENTS_EXCEEDED"
+	StatusMaxWaypointsExceeded = "MAX_WAYPOINTS_EXCEEDED"
+	StatusUnknownError         = "UNKNOWN_ERROR"
+	StatusTimeout              = "TIMEOUT"
+	StatusError                = "ERROR"
+	StatusDailyLimit           = "DAILY_LIMIT_REACHED"
+	StatusMissingInput         = "MISSING_INPUT"
+)
+
+// Status Codes Returned by the Google Maps Web Service APIs
+var apiStatuses = map[string]bool{
+	StatusOK:                   true,
+	StatusZeroResults:          true,
+	StatusNotFound:             true,
+	StatusOverQueryLimit:       true,
+	StatusOverDailyLimit:       true,
+	StatusRequestDenied:        true,
+	StatusInvalidRequest:       true,
+	StatusMaxElementsExceeded:  true,
+	StatusMaxWaypointsExceeded: true,
+	StatusUnknownError:         true,
+}
+
+// Backoff Interval Bounds for Retried Requests
+const (
+	backoffBase = 500 * time.Millisecond
+	backoffMax  = 32 * time.Second
+)
+
+// Classify API Request Error into a Status Code
+func ErrorStatus(err error) (status string) {
+	// Check for successful request
+	if err == nil {
+		return StatusOK
+	}
+	// Check for exhausted query budget
+	if err == ErrDailyLimit {
+		return StatusDailyLimit
+	}
+	// Check for timeouts
+	if err == context.DeadlineExceeded {
+		return StatusTimeout
+	}
+	if ne, ok := err.(net.Error); ok && ne.Timeout() {
+		return StatusTimeout
+	}
+	// Parse status from API error message format "maps: STATUS - message"
+	msg := err.Error()
+	if strings.HasPrefix(msg, "maps: ") {
+		status = strings.TrimPrefix(msg, "maps: ")
+		if i := strings.Index(status, " - "); i >= 0 {
+			status = status[:i]
+		}
+		// Treat client side validation messages as invalid requests
+		if apiStatuses[status] != true {
+			return StatusInvalidRequest
+		}
+		return status
+	}
+	// Default to generic transport error
+	return StatusError
+}
+
+// Check Whether a Request Status Should Be Retried
+func Transient(status string) (retry bool) {
+	switch status {
+	case StatusOverQueryLimit, StatusUnknownError, StatusTimeout, StatusError:
+		return true
+	default:
+		return false
+	}
+}
+
+// Compute Jittered Exponential Backoff Interval for a Retry Attempt
+func Backoff(attempt int) (wait time.Duration) {
+	// Double base interval per attempt up to the maximum
+	wait = backoffBase
+	for i := 0; i < attempt && wait < backoffMax; i++ {
+		wait *= 2
+	}
+	if wait > backoffMax {
+		wait = backoffMax
+	}
+	// Apply full jitter
+	return time.Duration(rand.Int63n(int64(wait)) + 1)
+}
+
+// Submit API Request with Rate Limiting and Retries on Transient Failures
+func SubmitRequest(con *cli.Context, lmt *RateLimiter, call func() error) (status string, e error) {
+	// Parse retry limit
+	retries := con.Int("max-retries")
+	// Enter retry loop
+	for attempt := 0; ; attempt++ {
+		// Wait on rate limiter and query budget
+		if err := lmt.Wait(); err != nil {
+			return StatusDailyLimit, err
+		}
+		// Submit request and classify errors
+		err := call()
+		status = ErrorStatus(err)
+		if err == nil || Transient(status) != true || attempt >= retries {
+			return status, err
+		}
+		// Sleep before next attempt
+		time.Sleep(Backoff(attempt))
+	}
+}
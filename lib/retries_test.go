@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Network Error Reporting a Timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"success", nil, StatusOK},
+		{"daily limit", ErrDailyLimit, StatusDailyLimit},
+		{"deadline", context.DeadlineExceeded, StatusTimeout},
+		{"network timeout", timeoutError{}, StatusTimeout},
+		{"api status with message", errors.New("maps: OVER_QUERY_LIMIT - You have exceeded your rate-limit"), StatusOverQueryLimit},
+		{"api status without message", errors.New("maps: REQUEST_DENIED - "), StatusRequestDenied},
+		{"api not found", errors.New("maps: NOT_FOUND - "), StatusNotFound},
+		{"client validation", errors.New("maps: Radius missing, required with Location"), StatusInvalidRequest},
+		{"client validation with dash", errors.New("maps: unknown Mode: 'teleport' - check flags"), StatusInvalidRequest},
+		{"transport", errors.New("dial tcp: connection refused"), StatusError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorStatus(tt.err); got != tt.want {
+				t.Errorf("ErrorStatus(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransient(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{StatusOK, false},
+		{StatusZeroResults, false},
+		{StatusOverQueryLimit, true},
+		{StatusOverDailyLimit, false},
+		{StatusRequestDenied, false},
+		{StatusInvalidRequest, false},
+		{StatusUnknownError, true},
+		{StatusTimeout, true},
+		{StatusError, true},
+		{StatusDailyLimit, false},
+		{StatusMissingInput, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := Transient(tt.status); got != tt.want {
+				t.Errorf("Transient(%q) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, backoffBase},
+		{1, 2 * backoffBase},
+		{3, 8 * backoffBase},
+		{6, backoffMax},
+		{20, backoffMax},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempt), func(t *testing.T) {
+			// Jittered waits stay within the doubled interval
+			for i := 0; i < 200; i++ {
+				if got := Backoff(tt.attempt); got <= 0 || got > tt.max {
+					t.Fatalf("Backoff(%d) = %v, want in (0, %v]", tt.attempt, got, tt.max)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"fmt"
+	"gopkg.in/urfave/cli.v1"
+	"os"
+)
+
+// Run Per-Record API Calls Through the Worker Pool with Checkpoint and Cache Support
+func runRecords[In any, Out any](con *cli.Context, records <-chan In, id func(rec In) string, call func(lmt *RateLimiter, cch *Cache, rec In) (out []Out, status string)) (results chan Out, e error) {
+	// Allocate empty variables
+	var err error = nil
+	// Open checkpoint file
+	chk, err := OpenCheckpoint(con)
+	if err != nil {
+		return nil, err
+	}
+	// Open response cache
+	cch, err := OpenCache(con)
+	if err != nil {
+		chk.Close()
+		return nil, err
+	}
+	// Open rate limiter and daily query budget
+	lmt, err := NewRateLimiter(con)
+	if err != nil {
+		chk.Close()
+		cch.Close()
+		return nil, err
+	}
+	// Allocate receiver variables
+	results = make(chan Out, channelBuffer)
+	bar := NewProgressBar()
+	pool := NewWorkerPool(con)
+	// Enter request loop
+	go func() {
+		for {
+			// Extract current record
+			rec, ok := <-records
+			if !ok {
+				break
+			}
+			// Submit record to worker pool
+			key := id(rec)
+			var out []Out
+			pool.Submit(func() {
+				// Restore finished records from checkpoint
+				if chk.Restore(key, &out) {
+					return
+				}
+				var status string
+				out, status = call(lmt, cch, rec)
+				chk.Append(key, status, out)
+			}, func() {
+				// Send results to channel
+				for _, r := range out {
+					results <- r
+				}
+				// Increment progress bar
+				bar.Increment()
+			})
+		}
+		// Wait for outstanding requests
+		pool.Wait()
+		// Close checkpoint file, response cache and usage counts
+		if err := chk.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		if err := cch.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		if err := lmt.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		// Finish progress bar
+		bar.Finish()
+		close(results)
+	}()
+	return results, err
+}
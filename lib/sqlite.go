@@ -0,0 +1,340 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"gopkg.in/urfave/cli.v1"
+	"strconv"
+	"strings"
+)
+
+// Rows Written per SQLite Transaction
+const sqliteBatch = 100
+
+// GeoPackage Application Id and Version
+const (
+	gpkgApplicationId = 0x47504B47
+	gpkgUserVersion   = 10200
+)
+
+// WGS 84 Spatial Reference System Definition
+const wgs84Definition = `GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563,AUTHORITY["EPSG","7030"]],AUTHORITY["EPSG","6326"]],PRIMEM["Greenwich",0,AUTHORITY["EPSG","8901"]],UNIT["degree",0.0174532925199433,AUTHORITY["EPSG","9122"]],AUTHORITY["EPSG","4326"]]`
+
+// GeoPackage Metadata Table Specification
+var gpkgSchema = []string{
+	`CREATE TABLE IF NOT EXISTS gpkg_spatial_ref_sys (
+		srs_name TEXT NOT NULL,
+		srs_id INTEGER NOT NULL PRIMARY KEY,
+		organization TEXT NOT NULL,
+		organization_coordsys_id INTEGER NOT NULL,
+		definition TEXT NOT NULL,
+		description TEXT)`,
+	`CREATE TABLE IF NOT EXISTS gpkg_contents (
+		table_name TEXT NOT NULL PRIMARY KEY,
+		data_type TEXT NOT NULL,
+		identifier TEXT UNIQUE,
+		description TEXT DEFAULT '',
+		last_change DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+		min_x DOUBLE,
+		min_y DOUBLE,
+		max_x DOUBLE,
+		max_y DOUBLE,
+		srs_id INTEGER,
+		CONSTRAINT fk_gc_r_srs_id FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys(srs_id))`,
+	`CREATE TABLE IF NOT EXISTS gpkg_geometry_columns (
+		table_name TEXT NOT NULL,
+		column_name TEXT NOT NULL,
+		geometry_type_name TEXT NOT NULL,
+		srs_id INTEGER NOT NULL,
+		z TINYINT NOT NULL,
+		m TINYINT NOT NULL,
+		CONSTRAINT pk_geom_cols PRIMARY KEY (table_name, column_name),
+		CONSTRAINT fk_gc_tn FOREIGN KEY (table_name) REFERENCES gpkg_contents(table_name),
+		CONSTRAINT fk_gc_srs FOREIGN KEY (srs_id) REFERENCES gpkg_spatial_ref_sys(srs_id))`,
+	`INSERT OR IGNORE INTO gpkg_spatial_ref_sys VALUES
+		('Undefined cartesian SRS', -1, 'NONE', -1, 'undefined', 'undefined cartesian coordinate reference system'),
+		('Undefined geographic SRS', 0, 'NONE', 0, 'undefined', 'undefined geographic coordinate reference system'),
+		('WGS 84 geodetic', 4326, 'EPSG', 4326, '` + wgs84Definition + `', 'longitude/latitude coordinates in decimal degrees on the WGS 84 spheroid')`,
+}
+
+// Output Columns Stored with Numeric Affinity
+var numericColumns = map[string]bool{
+	"rank":       true,
+	"lat":        true,
+	"lng":        true,
+	"radius":     true,
+	"elevation":  true,
+	"resolution": true,
+}
+
+// Define sqliteOutput Struct
+type sqliteOutput struct {
+	db      *sql.DB
+	tx      *sql.Tx
+	table   string
+	header  []string
+	gpkg    bool
+	id      int
+	insert  string
+	seen    map[string]bool
+	created bool
+	count   int
+}
+
+// Quote an SQL Identifier
+func quoteIdent(name string) (quoted string) {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// Open SQLite or GeoPackage Output Writer on Output and Table Flags
+func newSQLiteOutput(con *cli.Context, header []string, format string) (out *sqliteOutput, e error) {
+	// Require output database file
+	if con.IsSet("output") != true {
+		return nil, fmt.Errorf("gmaps: %s output requires an output file", format)
+	}
+	// Open output database
+	db, err := sql.Open("sqlite3", con.String("output"))
+	if err != nil {
+		return nil, err
+	}
+	// Locate id column for upserts
+	id := -1
+	for i, name := range header {
+		if name == "id" {
+			id = i
+			break
+		}
+	}
+	so := &sqliteOutput{
+		db:     db,
+		table:  con.String("table"),
+		header: header,
+		gpkg:   format == "gpkg",
+		id:     id,
+		seen:   make(map[string]bool),
+	}
+	if len(so.table) == 0 {
+		so.table = "results"
+	}
+	// Initialize geopackage metadata tables
+	if so.gpkg {
+		err = so.initGeoPackage()
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return so, nil
+}
+
+// Initialize GeoPackage Metadata Tables
+func (so *sqliteOutput) initGeoPackage() (e error) {
+	// Mark database as geopackage
+	_, err := so.db.Exec(fmt.Sprintf("PRAGMA application_id = %d; PRAGMA user_version = %d", gpkgApplicationId, gpkgUserVersion))
+	if err != nil {
+		return err
+	}
+	// Create metadata tables
+	for _, stmt := range gpkgSchema {
+		_, err = so.db.Exec(stmt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create or Extend Output Table with Header and Pass-Through Columns
+func (so *sqliteOutput) createTable(extra []string) (e error) {
+	so.created = true
+	// Name columns uniquely
+	var columns []string
+	used := map[string]bool{"fid": true, "geom": true}
+	for _, name := range append(append([]string{}, so.header...), extra...) {
+		unique := name
+		for n := 2; used[strings.ToLower(unique)]; n++ {
+			unique = name + "_" + strconv.Itoa(n)
+		}
+		used[strings.ToLower(unique)] = true
+		columns = append(columns, unique)
+	}
+	// Create table
+	geomType := "BLOB"
+	if so.gpkg {
+		geomType = "POINT"
+	}
+	_, err := so.db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (fid INTEGER PRIMARY KEY AUTOINCREMENT, geom %s)", quoteIdent(so.table), geomType))
+	if err != nil {
+		return err
+	}
+	// Collect existing columns
+	rows, err := so.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", quoteIdent(so.table)))
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var cid, notnull, pk int
+		var name, ctype string
+		var dflt sql.NullString
+		err = rows.Scan(&cid, &name, &ctype, &notnull, &dflt, &pk)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		existing[strings.ToLower(name)] = true
+	}
+	rows.Close()
+	// Add missing columns
+	for _, name := range columns {
+		if existing[strings.ToLower(name)] {
+			continue
+		}
+		affinity := "TEXT"
+		if numericColumns[name] {
+			affinity = "NUMERIC"
+		}
+		_, err = so.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", quoteIdent(so.table), quoteIdent(name), affinity))
+		if err != nil {
+			return err
+		}
+	}
+	// Index id column for upserts
+	if so.id >= 0 {
+		_, err = so.db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)",
+			quoteIdent(so.table+"_id"), quoteIdent(so.table), quoteIdent(columns[so.id])))
+		if err != nil {
+			return err
+		}
+	}
+	// Register geopackage feature table
+	if so.gpkg {
+		_, err = so.db.Exec("INSERT OR IGNORE INTO gpkg_contents (table_name, data_type, identifier, srs_id) VALUES (?, 'features', ?, 4326)", so.table, so.table)
+		if err != nil {
+			return err
+		}
+		_, err = so.db.Exec("INSERT OR IGNORE INTO gpkg_geometry_columns VALUES (?, 'geom', 'POINT', 4326, 0, 0)", so.table)
+		if err != nil {
+			return err
+		}
+	}
+	// Prepare insert statement
+	quoted := []string{"geom"}
+	for _, name := range columns {
+		quoted = append(quoted, quoteIdent(name))
+	}
+	so.insert = fmt.Sprintf("INSERT INTO %s (%s) VALUES (?%s)",
+		quoteIdent(so.table), strings.Join(quoted, ", "), strings.Repeat(", ?", len(columns)))
+	return nil
+}
+
+// Encode Point Geometry as WKB or GeoPackage Binary
+func (so *sqliteOutput) pointBlob(lat, lng float64) (blob []byte) {
+	var buf bytes.Buffer
+	// Write geopackage binary header
+	if so.gpkg {
+		buf.Write([]byte{'G', 'P', 0, 1})
+		binary.Write(&buf, binary.LittleEndian, int32(4326))
+	}
+	// Write little endian WKB point
+	buf.WriteByte(1)
+	binary.Write(&buf, binary.LittleEndian, uint32(1))
+	binary.Write(&buf, binary.LittleEndian, [2]float64{lng, lat})
+	return buf.Bytes()
+}
+
+// Define Write Method for sqliteOutput Struct
+func (so *sqliteOutput) Write(rec interface{}, row []string, extra Passthrough) (e error) {
+	var err error = nil
+	// Create table ahead of first row
+	if so.created != true {
+		err = so.createTable(extra.Header)
+		if err != nil {
+			return err
+		}
+	}
+	// Begin transaction
+	if so.tx == nil {
+		so.tx, err = so.db.Begin()
+		if err != nil {
+			return err
+		}
+	}
+	// Replace rows from earlier runs once per id
+	if so.id >= 0 && so.seen[row[so.id]] != true {
+		so.seen[row[so.id]] = true
+		_, err = so.tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", quoteIdent(so.table), quoteIdent(so.header[so.id])), row[so.id])
+		if err != nil {
+			return err
+		}
+	}
+	// Collect row values
+	args := []interface{}{nil}
+	if lat, lng, ok := recordPoint(rec); ok {
+		args[0] = so.pointBlob(lat, lng)
+	}
+	for _, value := range append(append([]string{}, row...), extra.Values...) {
+		args = append(args, value)
+	}
+	// Insert row
+	_, err = so.tx.Exec(so.insert, args...)
+	if err != nil {
+		return err
+	}
+	// Commit full batches
+	so.count++
+	if so.count%sqliteBatch == 0 {
+		err = so.tx.Commit()
+		so.tx = nil
+	}
+	return err
+}
+
+// Define Close Method for sqliteOutput Struct
+func (so *sqliteOutput) Close() (e error) {
+	var err error = nil
+	// Create table for empty results
+	if so.created != true {
+		err = so.createTable(nil)
+	}
+	// Commit open transaction
+	if so.tx != nil {
+		if cerr := so.tx.Commit(); err == nil {
+			err = cerr
+		}
+	}
+	// Update geopackage change time
+	if so.gpkg && err == nil {
+		_, err = so.db.Exec("UPDATE gpkg_contents SET last_change = strftime('%Y-%m-%dT%H:%M:%fZ','now') WHERE table_name = ?", so.table)
+	}
+	// Close output database
+	if cerr := so.db.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
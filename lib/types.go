@@ -28,35 +28,195 @@ import (
 
 // Geocode Record Struct Field Specification
 type GeocodeRecord struct {
-	Id      string
-	Address string
-	Lat     float64
-	Lng     float64
-	Region  string
-	Note    string
+	Id         string        `json:"id"`
+	Rank       int           `json:"rank"`
+	Address    string        `json:"address"`
+	Street     string        `json:"street,omitempty"`
+	City       string        `json:"city,omitempty"`
+	State      string        `json:"state,omitempty"`
+	PostalCode string        `json:"postal_code,omitempty"`
+	Country    string        `json:"country,omitempty"`
+	Lat        float64       `json:"lat"`
+	Lng        float64       `json:"lng"`
+	Region     string        `json:"region,omitempty"`
+	Detail     GeocodeDetail `json:"detail"`
+	Status     string        `json:"status"`
+	Note       string        `json:"note"`
+	Extra      Passthrough   `json:"extra,omitempty"`
+}
+
+// Geocode Result Detail Struct Field Specification
+type GeocodeDetail struct {
+	FormattedAddress string   `json:"formatted_address"`
+	PlaceId          string   `json:"place_id"`
+	LocationType     string   `json:"location_type"`
+	PartialMatch     bool     `json:"partial_match"`
+	Types            []string `json:"types"`
+	StreetNumber     string   `json:"street_number"`
+	Route            string   `json:"route"`
+	Locality         string   `json:"locality"`
+	County           string   `json:"county"`
+	State            string   `json:"state"`
+	PostalCode       string   `json:"postal_code"`
+	Country          string   `json:"country"`
 }
 
 // Elevation Record Struct Field Spedification
 type ElevationRecord struct {
-	Id         string
-	Elevation  float64
-	Lat        float64
-	Lng        float64
-	Resolution float64
-	Note       string
+	Id         string      `json:"id"`
+	Elevation  float64     `json:"elevation"`
+	Lat        float64     `json:"lat"`
+	Lng        float64     `json:"lng"`
+	Resolution float64     `json:"resolution"`
+	Status     string      `json:"status"`
+	Note       string      `json:"note"`
+	Extra      Passthrough `json:"extra,omitempty"`
 }
 
 // Place Nearby Record Struct Field Specification
 type PlaceRecord struct {
-	Id       string
-	Lat      float64
-	Lng      float64
-	Radius   uint
-	PlaceId  string
-	Name     string
-	Type     string
-	Scope    string
-	Bounds   maps.LatLngBounds
-	Viewport maps.LatLngBounds
-	Note     string
+	Id       string            `json:"id"`
+	Rank     int               `json:"rank"`
+	Query    string            `json:"query,omitempty"`
+	Lat      float64           `json:"lat"`
+	Lng      float64           `json:"lng"`
+	Radius   uint              `json:"radius"`
+	PlaceId  string            `json:"place_id"`
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Bounds   maps.LatLngBounds `json:"bounds"`
+	Viewport maps.LatLngBounds `json:"viewport"`
+	Filter   PlaceFilter       `json:"filter"`
+	Detail   PlaceDetail       `json:"detail"`
+	Status   string            `json:"status"`
+	Note     string            `json:"note"`
+	Extra    Passthrough       `json:"extra,omitempty"`
+}
+
+// Place Detail Result Struct Field Specification
+type PlaceDetail struct {
+	FormattedAddress string   `json:"formatted_address"`
+	Phone            string   `json:"phone"`
+	Website          string   `json:"website"`
+	Rating           float32  `json:"rating"`
+	UserRatingsTotal int      `json:"user_ratings_total"`
+	OpeningHours     []string `json:"opening_hours"`
+	Types            []string `json:"types"`
+}
+
+// Place Search Filter Struct Field Specification
+type PlaceFilter struct {
+	Keyword  string `json:"keyword,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Language string `json:"language,omitempty"`
+	MinPrice string `json:"min_price,omitempty"`
+	MaxPrice string `json:"max_price,omitempty"`
+	OpenNow  bool   `json:"open_now,omitempty"`
+	RankBy   string `json:"rank_by,omitempty"`
+}
+
+// Place Autocomplete Record Struct Field Specification
+type AutocompleteRecord struct {
+	Id            string                              `json:"id"`
+	Rank          int                                 `json:"rank"`
+	Input         string                              `json:"input"`
+	Session       string                              `json:"session,omitempty"`
+	Token         maps.PlaceAutocompleteSessionToken  `json:"-"`
+	Lat           float64                             `json:"lat"`
+	Lng           float64                             `json:"lng"`
+	Radius        uint                                `json:"radius"`
+	PlaceId       string                              `json:"place_id"`
+	Description   string                              `json:"description"`
+	MainText      string                              `json:"main_text"`
+	SecondaryText string                              `json:"secondary_text"`
+	Types         []string                            `json:"types"`
+	Matched       []maps.AutocompleteMatchedSubstring `json:"matched_substrings"`
+	Status        string                              `json:"status"`
+	Note          string                              `json:"note"`
+	Extra         Passthrough                         `json:"extra,omitempty"`
+}
+
+// Directions Record Struct Field Specification
+type DirectionsRecord struct {
+	Id                string      `json:"id"`
+	Origin            string      `json:"origin"`
+	Destination       string      `json:"destination"`
+	Waypoints         []string    `json:"waypoints,omitempty"`
+	Mode              string      `json:"mode,omitempty"`
+	DepartureTime     string      `json:"departure_time,omitempty"`
+	Avoid             []string    `json:"avoid,omitempty"`
+	Distance          int         `json:"distance_m"`
+	Duration          int         `json:"duration_s"`
+	DurationInTraffic int         `json:"duration_in_traffic_s"`
+	Summary           string      `json:"summary"`
+	Polyline          string      `json:"polyline"`
+	Status            string      `json:"status"`
+	Note              string      `json:"note"`
+	Extra             Passthrough `json:"extra,omitempty"`
+}
+
+// Distance Matrix Location Struct Field Specification
+type MatrixPoint struct {
+	Id       string `json:"id"`
+	Location string `json:"location"`
+}
+
+// Distance Matrix Element Record Struct Field Specification
+type MatrixRecord struct {
+	OriginId          string `json:"origin_id"`
+	DestinationId     string `json:"destination_id"`
+	OriginIndex       int    `json:"origin_index"`
+	DestinationIndex  int    `json:"destination_index"`
+	Origin            string `json:"origin"`
+	Destination       string `json:"destination"`
+	Distance          int    `json:"distance_m"`
+	Duration          int    `json:"duration_s"`
+	DurationInTraffic int    `json:"duration_in_traffic_s"`
+	Status            string `json:"status"`
+	Note              string `json:"note"`
+}
+
+// Time Zone Record Struct Field Specification
+type TimezoneRecord struct {
+	Id           string      `json:"id"`
+	Lat          float64     `json:"lat"`
+	Lng          float64     `json:"lng"`
+	Timestamp    int64       `json:"timestamp"`
+	TimeZoneId   string      `json:"time_zone_id"`
+	TimeZoneName string      `json:"time_zone_name"`
+	RawOffset    int         `json:"raw_offset"`
+	DstOffset    int         `json:"dst_offset"`
+	Status       string      `json:"status"`
+	Note         string      `json:"note"`
+	Extra        Passthrough `json:"extra,omitempty"`
+}
+
+// Roads Trace Point Struct Field Specification
+type RoadPoint struct {
+	Id    string      `json:"id"`
+	Lat   float64     `json:"lat"`
+	Lng   float64     `json:"lng"`
+	Extra Passthrough `json:"extra,omitempty"`
+}
+
+// Roads Trace Struct Field Specification
+type RoadTrace struct {
+	Id     string       `json:"id"`
+	Points []*RoadPoint `json:"points"`
+}
+
+// Roads Snapped Point Record Struct Field Specification
+type RoadRecord struct {
+	TraceId       string      `json:"trace_id"`
+	PointId       string      `json:"point_id"`
+	OriginalIndex int         `json:"original_index"`
+	Lat           float64     `json:"lat"`
+	Lng           float64     `json:"lng"`
+	PlaceId       string      `json:"place_id"`
+	SpeedLimit    float64     `json:"speed_limit,omitempty"`
+	Units         string      `json:"units,omitempty"`
+	Status        string      `json:"status"`
+	Note          string      `json:"note"`
+	Extra         Passthrough `json:"extra,omitempty"`
 }
@@ -23,10 +23,47 @@ SOFTWARE.
 package gmaps
 
 import (
+	"fmt"
+	"gopkg.in/cheggaaa/pb.v1"
+	"gopkg.in/urfave/cli.v1"
+	"os"
 	"os/user"
 	"path/filepath"
 )
 
+// Buffer Size for Channels Streaming Records Between Pipeline Stages
+const channelBuffer = 100
+
+// Maximum Number of Ranked Candidates Emitted per Input Record
+func MaxResults(con *cli.Context) (n int) {
+	n = con.Int("max-results")
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// Describe a Successful Ranked Result Set by the Number of Rows Emitted
+func CandidateNote(found int, emitted int, kind string) (note string) {
+	switch {
+	case emitted > 1:
+		return fmt.Sprintf("Success: %d Results Returned", emitted)
+	case found > emitted:
+		return "Success: Multiple " + kind + " Found - First Retrieved"
+	default:
+		return "Success"
+	}
+}
+
+// Start Record Counter Progress Bar on Stderr
+func NewProgressBar() (bar *pb.ProgressBar) {
+	// Allocate bar without a known total
+	bar = pb.New(0)
+	// Keep stdout free for streamed results
+	bar.Output = os.Stderr
+	return bar.Start()
+}
+
 // Function for Formatting API Call Response Output Filepath
 func OutputFilepath(fp string) (out string, e error) {
 	// Allocate vars
@@ -48,3 +85,25 @@ func OutputFilepath(fp string) (out string, e error) {
 	}
 	return output, err
 }
+
+// Function for Formatting Response Cache Database Filepath
+func CacheFilepath(fp string) (out string, e error) {
+	// Allocate vars
+	var err error = nil
+	var output string
+	// Parse command line arguments
+	if len(fp) == 0 {
+		// Get user info
+		usr, err := user.Current()
+		if err != nil {
+			output = "None"
+			return output, err
+		}
+		// Set default cache next to default results file
+		output = filepath.Join(usr.HomeDir, "gmaps_cache.db")
+	} else {
+		// Format cache filename
+		output = fp
+	}
+	return output, err
+}
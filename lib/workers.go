@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"gopkg.in/urfave/cli.v1"
+	"sync"
+)
+
+// Worker Pool Struct Field Specification
+type WorkerPool struct {
+	workers  int
+	ordered  bool
+	seq      int
+	jobs     chan *poolJob
+	finished chan *poolJob
+	wg       sync.WaitGroup
+	done     sync.WaitGroup
+}
+
+// Pool Job Struct Field Specification
+type poolJob struct {
+	seq  int
+	work func()
+	emit func()
+}
+
+// Initialize New Worker Pool from Context Flags
+func NewWorkerPool(con *cli.Context) (pool *WorkerPool) {
+	// Parse worker count
+	n := con.Int("workers")
+	if n < 1 {
+		n = 1
+	}
+	// Allocate pool
+	pool = &WorkerPool{
+		workers:  n,
+		ordered:  con.Bool("ordered"),
+		jobs:     make(chan *poolJob, n),
+		finished: make(chan *poolJob, n),
+	}
+	// Start workers
+	pool.wg.Add(n)
+	for i := 0; i < n; i++ {
+		go pool.work()
+	}
+	// Start emitter
+	pool.done.Add(1)
+	go pool.emit()
+	return pool
+}
+
+// Submit Work and Emit Functions to the Worker Pool
+func (p *WorkerPool) Submit(work func(), emit func()) {
+	// Send job to workers
+	p.jobs <- &poolJob{
+		seq:  p.seq,
+		work: work,
+		emit: emit,
+	}
+	// Increment sequence counter
+	p.seq++
+}
+
+// Wait for All Submitted Jobs to Be Worked and Emitted
+func (p *WorkerPool) Wait() {
+	// Close job queue and wait for workers
+	close(p.jobs)
+	p.wg.Wait()
+	// Close finished queue and wait for emitter
+	close(p.finished)
+	p.done.Wait()
+}
+
+// Worker Loop Executing Submitted Jobs
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		job.work()
+		p.finished <- job
+	}
+}
+
+// Emitter Loop Releasing Finished Jobs in Completion or Input Order
+func (p *WorkerPool) emit() {
+	defer p.done.Done()
+	// Allocate reorder buffer
+	next := 0
+	pending := make(map[int]*poolJob)
+	for job := range p.finished {
+		// Emit immediately when order is not required
+		if p.ordered != true {
+			job.emit()
+			continue
+		}
+		// Buffer until the next expected job has finished
+		pending[job.seq] = job
+		for {
+			j, ok := pending[next]
+			if !ok {
+				break
+			}
+			j.emit()
+			delete(pending, next)
+			next++
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2018 Eric Daniel Fournier
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package gmaps
+
+import (
+	"flag"
+	"sort"
+	"testing"
+	"time"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+// Build a Context with Flags Set as If Parsed from the Command Line
+func testContext(t *testing.T, flags map[string]string) (con *cli.Context) {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	for name, value := range flags {
+		fs.String(name, "", "")
+		if err := fs.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cli.NewContext(nil, fs, nil)
+}
+
+func TestWorkerPoolEmit(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers string
+		ordered string
+	}{
+		{"serial", "1", "false"},
+		{"serial ordered", "1", "true"},
+		{"parallel", "8", "false"},
+		{"parallel ordered", "8", "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewWorkerPool(testContext(t, map[string]string{
+				"workers": tt.workers,
+				"ordered": tt.ordered,
+			}))
+			// Finish early jobs last so completion order differs from input order
+			const jobs = 40
+			var emitted []int
+			for i := 0; i < jobs; i++ {
+				seq := i
+				pool.Submit(func() {
+					time.Sleep(time.Duration(jobs-seq) * 100 * time.Microsecond)
+				}, func() {
+					emitted = append(emitted, seq)
+				})
+			}
+			pool.Wait()
+			if len(emitted) != jobs {
+				t.Fatalf("emitted %d jobs, want %d", len(emitted), jobs)
+			}
+			// Ordered pools and single workers preserve input order
+			inOrder := sort.IntsAreSorted(emitted)
+			if (tt.ordered == "true" || tt.workers == "1") && inOrder != true {
+				t.Errorf("emitted out of input order: %v", emitted)
+			}
+			sorted := append([]int(nil), emitted...)
+			sort.Ints(sorted)
+			for i, seq := range sorted {
+				if seq != i {
+					t.Fatalf("emitted jobs %v, want each of 0..%d once", emitted, jobs-1)
+				}
+			}
+		})
+	}
+}